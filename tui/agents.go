@@ -0,0 +1,279 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os/exec"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// agents.go - Headless agent launches managed by the TUI
+// Runs a column's assigned agent on a task with the rendered step prompt,
+// streams its output into AgentInfo.Logs and records how it finished
+
+// maxAgentLogLines caps how many output lines are kept per task
+const maxAgentLogLines = 200
+
+// agentOutputMsg carries a line of output from a running agent
+type agentOutputMsg struct {
+	taskID string
+	line   string
+}
+
+// agentExitedMsg is sent when an agent process exits
+type agentExitedMsg struct {
+	taskID string
+	err    error
+	killed bool // Stopped from the fleet view
+}
+
+// agentCommand builds the headless command for an agent type
+// The prompt is passed as a single argument, never through a shell; sessionID
+// (Claude only) lets a later chat resume the run's conversation
+func agentCommand(agentType AgentType, prompt, sessionID string) (*exec.Cmd, error) {
+	switch agentType {
+	case AgentClaudeCode, "":
+		if sessionID != "" {
+			return exec.Command("claude", "-p", "--session-id", sessionID, prompt), nil
+		}
+		return exec.Command("claude", "-p", prompt), nil
+	case AgentCodex:
+		return exec.Command("codex", "exec", prompt), nil
+	case AgentGeminiCLI:
+		return exec.Command("gemini", "-p", prompt), nil
+	}
+	return nil, fmt.Errorf("no headless command for agent %s", agentType)
+}
+
+// agentManager tracks the agent processes started by this TUI
+// Events are delivered to the model through a channel read by waitForEvent
+type agentManager struct {
+	mu     sync.Mutex
+	procs  map[string]*exec.Cmd // Running processes by task ID
+	killed map[string]bool      // Tasks whose agent was killed from the fleet view
+	events chan tea.Msg
+}
+
+// newAgentManager creates an empty agent manager
+func newAgentManager() *agentManager {
+	return &agentManager{
+		procs:  make(map[string]*exec.Cmd),
+		killed: make(map[string]bool),
+		events: make(chan tea.Msg, 64),
+	}
+}
+
+// waitForEvent returns a command that delivers the next agent event
+// The model re-arms it after handling each event
+func (a *agentManager) waitForEvent() tea.Cmd {
+	return func() tea.Msg {
+		return <-a.events
+	}
+}
+
+// isRunning reports whether this TUI is running an agent for the task
+func (a *agentManager) isRunning(taskID string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	_, ok := a.procs[taskID]
+	return ok
+}
+
+// start launches an agent for a task in dir (empty for the current directory)
+func (a *agentManager) start(taskID string, agentType AgentType, prompt, sessionID, dir string) error {
+	if a.isRunning(taskID) {
+		return fmt.Errorf("an agent is already running for %s", taskID)
+	}
+
+	cmd, err := agentCommand(agentType, prompt, sessionID)
+	if err != nil {
+		return err
+	}
+	cmd.Dir = dir
+	setAgentProcessGroup(cmd)
+
+	// Interleave stdout and stderr into one log stream
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	cmd.Stderr = cmd.Stdout
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start %s: %w", agentType, err)
+	}
+
+	a.mu.Lock()
+	a.procs[taskID] = cmd
+	a.mu.Unlock()
+
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			a.events <- agentOutputMsg{taskID: taskID, line: scanner.Text()}
+		}
+		// Keep reading past an overlong line so the agent doesn't block on a full pipe
+		if err := scanner.Err(); err != nil {
+			a.events <- agentOutputMsg{taskID: taskID, line: "[rest of the output not logged: " + err.Error() + "]"}
+			io.Copy(io.Discard, stdout)
+		}
+
+		err := cmd.Wait()
+		a.mu.Lock()
+		delete(a.procs, taskID)
+		killed := a.killed[taskID]
+		delete(a.killed, taskID)
+		a.mu.Unlock()
+		a.events <- agentExitedMsg{taskID: taskID, err: err, killed: killed}
+	}()
+
+	return nil
+}
+
+// process returns the running process for a task
+func (a *agentManager) process(taskID string) (*exec.Cmd, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	cmd, ok := a.procs[taskID]
+	if !ok {
+		return nil, fmt.Errorf("no agent running for %s", taskID)
+	}
+	return cmd, nil
+}
+
+// pause stops a running agent until resume is called
+func (a *agentManager) pause(taskID string) error {
+	cmd, err := a.process(taskID)
+	if err != nil {
+		return err
+	}
+	return pauseAgentProcess(cmd)
+}
+
+// resume continues a paused agent
+func (a *agentManager) resume(taskID string) error {
+	cmd, err := a.process(taskID)
+	if err != nil {
+		return err
+	}
+	return resumeAgentProcess(cmd)
+}
+
+// kill terminates an agent; the exit event is flagged as killed
+func (a *agentManager) kill(taskID string) error {
+	cmd, err := a.process(taskID)
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	a.killed[taskID] = true
+	a.mu.Unlock()
+	return killAgentProcess(cmd)
+}
+
+// launchAgent runs the current column's assigned agent on the selected task
+func (m *Model) launchAgent() {
+	task := m.getCurrentTask()
+	col := m.getCurrentColumn()
+	if task == nil || col == nil || m.agents == nil {
+		return
+	}
+
+	agentType := col.AssignedAgent
+	if agentType == "" {
+		agentType = AgentClaudeCode
+	}
+
+	dir := ""
+	if task.Git != nil {
+		dir = task.Git.Worktree
+	}
+
+	// Give the run its own session so a chat can pick it up afterwards
+	sessionID := ""
+	if supportsSessionID(agentType) {
+		sessionID = newSessionID()
+	}
+
+	prompt := buildTaskPrompt(task, col, m.fetchIssueDetails(), m.board.Context)
+	if err := m.agents.start(task.ID, agentType, prompt, sessionID, dir); err != nil {
+		m.statusMessage = fmt.Sprintf("Agent (%s) failed to start: %v", agentType, err)
+		return
+	}
+
+	now := time.Now()
+	task.Agent = &AgentInfo{
+		Type:      agentType,
+		Status:    AgentRunning,
+		SessionID: sessionID,
+		StartedAt: &now,
+	}
+	if sessionID != "" {
+		task.Sessions = append(task.Sessions, ChatSession{
+			ID:        sessionID,
+			Agent:     agentType,
+			Kind:      sessionKindRun,
+			Column:    col.Title,
+			StartedAt: now,
+			LastUsed:  now,
+		})
+	}
+	task.UpdatedAt = now
+	if m.backend != nil {
+		if err := m.backend.UpdateTask(task); err != nil {
+			m.statusMessage = "Agent started, but its state wasn't saved: " + err.Error()
+		}
+	}
+}
+
+// handleAgentOutput appends an output line to the task's agent log
+func (m *Model) handleAgentOutput(msg agentOutputMsg) {
+	task := findTaskByID(m.board, msg.taskID)
+	if task == nil || task.Agent == nil {
+		return
+	}
+
+	task.Agent.Logs = append(task.Agent.Logs, msg.line)
+	if len(task.Agent.Logs) > maxAgentLogLines {
+		task.Agent.Logs = task.Agent.Logs[len(task.Agent.Logs)-maxAgentLogLines:]
+	}
+}
+
+// handleAgentExited records how an agent finished, persists its log and
+// runs the column's auto-advance rule (unless it was killed by the user)
+func (m *Model) handleAgentExited(msg agentExitedMsg) {
+	task := findTaskByID(m.board, msg.taskID)
+	if task == nil || task.Agent == nil {
+		return
+	}
+
+	if msg.killed {
+		task.Agent.Status = AgentFailed
+		task.Agent.Logs = append(task.Agent.Logs, "agent killed from the fleet view")
+	} else if msg.err != nil {
+		task.Agent.Status = AgentFailed
+		task.Agent.Logs = append(task.Agent.Logs, fmt.Sprintf("agent exited: %v", msg.err))
+	} else {
+		task.Agent.Status = AgentCompleted
+	}
+	task.UpdatedAt = time.Now()
+
+	if m.backend != nil {
+		if err := m.backend.UpdateTask(task); err != nil {
+			m.statusMessage = "Agent log not saved: " + err.Error()
+		}
+	}
+
+	if task.Agent.Status == AgentCompleted {
+		m.postAgentSummary(task)
+	}
+
+	if !msg.killed {
+		m.applyAdvanceRules(task, task.Agent.Status)
+	}
+}
@@ -0,0 +1,37 @@
+//go:build !windows
+
+package main
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// agents_unix.go - Process control for agents on Unix
+
+// setAgentProcessGroup starts the agent in its own process group so signals
+// reach any children it spawns
+func setAgentProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// signalAgentGroup sends sig to the agent's process group
+func signalAgentGroup(cmd *exec.Cmd, sig syscall.Signal) error {
+	return syscall.Kill(-cmd.Process.Pid, sig)
+}
+
+// pauseAgentProcess stops the agent until it is resumed
+func pauseAgentProcess(cmd *exec.Cmd) error {
+	return signalAgentGroup(cmd, syscall.SIGSTOP)
+}
+
+// resumeAgentProcess continues a paused agent
+func resumeAgentProcess(cmd *exec.Cmd) error {
+	return signalAgentGroup(cmd, syscall.SIGCONT)
+}
+
+// killAgentProcess terminates the agent, waking it first in case it is paused
+func killAgentProcess(cmd *exec.Cmd) error {
+	signalAgentGroup(cmd, syscall.SIGCONT)
+	return signalAgentGroup(cmd, syscall.SIGTERM)
+}
@@ -0,0 +1,32 @@
+//go:build windows
+
+package main
+
+import (
+	"errors"
+	"os/exec"
+)
+
+// agents_windows.go - Process control for agents on Windows
+// Windows has no job-control signals, so agents can be killed but not paused
+
+// errPauseUnsupported is returned for pause/resume on Windows
+var errPauseUnsupported = errors.New("pausing agents is not supported on Windows")
+
+// setAgentProcessGroup is a no-op on Windows
+func setAgentProcessGroup(cmd *exec.Cmd) {}
+
+// pauseAgentProcess is not supported on Windows
+func pauseAgentProcess(cmd *exec.Cmd) error {
+	return errPauseUnsupported
+}
+
+// resumeAgentProcess is not supported on Windows
+func resumeAgentProcess(cmd *exec.Cmd) error {
+	return errPauseUnsupported
+}
+
+// killAgentProcess terminates the agent
+func killAgentProcess(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -217,3 +218,36 @@ func SaveBoard(filePath string, board *Board) error {
 	backend := NewLocalBackend(filePath)
 	return backend.SaveBoard(board)
 }
+
+// findTaskByID returns the task with the given ID, or nil if not found
+func findTaskByID(board *Board, taskID string) *Task {
+	for _, task := range board.Tasks {
+		if task.ID == taskID {
+			return task
+		}
+	}
+	return nil
+}
+
+// findColumnByID returns the column with the given ID, or nil if not found
+func findColumnByID(board *Board, columnID string) *Column {
+	for i := range board.Columns {
+		if board.Columns[i].ID == columnID {
+			return &board.Columns[i]
+		}
+	}
+	return nil
+}
+
+// resolveColumn finds a column by ID or, failing that, by case-insensitive title
+func resolveColumn(board *Board, ref string) *Column {
+	if col := findColumnByID(board, ref); col != nil {
+		return col
+	}
+	for i := range board.Columns {
+		if strings.EqualFold(board.Columns[i].Title, ref) {
+			return &board.Columns[i]
+		}
+	}
+	return nil
+}
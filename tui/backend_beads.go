@@ -1,40 +1,96 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 	"os/exec"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
-// BeadsBackend implements Backend using the beads CLI (bd command)
+// BeadsBackend implements Backend using the beads CLI (bd command), reading
+// .beads/issues.jsonl directly when bd is unavailable (see beads_jsonl.go)
 type BeadsBackend struct {
 	// Cache the board to avoid excessive bd calls
 	cachedBoard *Board
 	lastLoad    time.Time
 	cacheTTL    time.Duration
-	showAll     bool // Include closed issues
+	showAll     bool                   // Include closed issues
+	parents     map[string]string      // Issue ID -> parent epic as last loaded
+	loaded      map[string]beadsFields // Issue ID -> editable fields as last loaded or written
+	statuses    map[string]string      // Issue ID -> status as last loaded or set
+
+	columnStatus   map[string]string // Column ID -> status, from the last load
+	customStatuses []string          // bd config status.custom
+	customLoaded   bool
+
+	issuesFile  string       // JSONL export read without bd
+	jsonlOnly   bool         // Always read issuesFile, never bd list (--beads-jsonl)
+	source      string       // beadsSourceBD or beadsSourceJSONL, as last loaded
+	jsonlIssues []BeadsIssue // Every issue from the last JSONL read, closed included
 }
 
 // BeadsIssue represents an issue from bd list --json
 type BeadsIssue struct {
-	ID              string    `json:"id"`
-	Title           string    `json:"title"`
-	Description     string    `json:"description"`
-	Status          string    `json:"status"` // open, in_progress, closed
-	Priority        int       `json:"priority"`
-	IssueType       string    `json:"issue_type"`
-	CreatedAt       time.Time `json:"created_at"`
-	CreatedBy       string    `json:"created_by"`
-	UpdatedAt       time.Time `json:"updated_at"`
-	ClosedAt        time.Time `json:"closed_at,omitempty"`
-	Assignee        string    `json:"assignee,omitempty"`
-	BlockedBy       []string  `json:"blocked_by,omitempty"`
-	Blocking        []string  `json:"blocking,omitempty"`
-	DependencyCount int       `json:"dependency_count"`
-	DependentCount  int       `json:"dependent_count"`
+	ID              string     `json:"id"`
+	Title           string     `json:"title"`
+	Description     string     `json:"description"`
+	Status          string     `json:"status"` // open, in_progress, closed
+	Priority        int        `json:"priority"`
+	IssueType       string     `json:"issue_type"`
+	CreatedAt       time.Time  `json:"created_at"`
+	CreatedBy       string     `json:"created_by"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	ClosedAt        time.Time  `json:"closed_at,omitempty"`
+	CloseReason     string     `json:"close_reason,omitempty"`
+	DeferUntil      *time.Time `json:"defer_until,omitempty"`
+	DueAt           *time.Time `json:"due_at,omitempty"`
+	ExternalRef     string     `json:"external_ref,omitempty"`
+	Estimate        *int       `json:"estimated_minutes,omitempty"`
+	Assignee        string     `json:"assignee,omitempty"`
+	Labels          []string   `json:"labels,omitempty"`
+	BlockedBy       []string   `json:"blocked_by,omitempty"`
+	Blocking        []string   `json:"blocking,omitempty"`
+	DependencyCount int        `json:"dependency_count"`
+	DependentCount  int        `json:"dependent_count"`
+
+	Parent       string                `json:"parent,omitempty"`
+	Dependencies []BeadsDependencyLink `json:"dependencies,omitempty"`
+	Comments     []BeadsComment        `json:"comments,omitempty"` // In the JSONL export
+}
+
+// BeadsComment is a comment on a beads issue
+type BeadsComment struct {
+	Author    string    `json:"author"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BeadsDependencyLink is a dependency as stored on an issue in bd list --json
+type BeadsDependencyLink struct {
+	IssueID     string `json:"issue_id"`
+	DependsOnID string `json:"depends_on_id"`
+	Type        string `json:"type"` // "blocks", "parent-child", ...
+}
+
+// parentID returns the issue's parent epic, if any
+func (issue BeadsIssue) parentID() string {
+	if issue.Parent != "" {
+		return issue.Parent
+	}
+	for _, dep := range issue.Dependencies {
+		if dep.Type == beadsParentChild {
+			return dep.DependsOnID
+		}
+	}
+	return ""
 }
 
 // BeadsIssueDependency represents a dependency/dependent from bd show --json
@@ -49,9 +105,12 @@ type BeadsIssueDependency struct {
 	CreatedBy      string    `json:"created_by"`
 	UpdatedAt      time.Time `json:"updated_at"`
 	ClosedAt       time.Time `json:"closed_at,omitempty"`
-	DependencyType string    `json:"dependency_type"` // "blocks"
+	DependencyType string    `json:"dependency_type"` // "blocks" or "parent-child"
 }
 
+// beadsParentChild is the dependency type linking a child issue to its epic
+const beadsParentChild = "parent-child"
+
 // BeadsIssueDetails represents the full issue details from bd show --json
 type BeadsIssueDetails struct {
 	ID           string                 `json:"id"`
@@ -64,9 +123,34 @@ type BeadsIssueDetails struct {
 	CreatedBy    string                 `json:"created_by"`
 	UpdatedAt    time.Time              `json:"updated_at"`
 	ClosedAt     time.Time              `json:"closed_at,omitempty"`
+	CloseReason  string                 `json:"close_reason,omitempty"`
+	DeferUntil   *time.Time             `json:"defer_until,omitempty"`
 	Assignee     string                 `json:"assignee,omitempty"`
+	Labels       []string               `json:"labels,omitempty"`
 	Dependencies []BeadsIssueDependency `json:"dependencies,omitempty"` // Issues that block this one
 	Dependents   []BeadsIssueDependency `json:"dependents,omitempty"`   // Issues this one blocks
+	Comments     []BeadsComment         `json:"comments,omitempty"`
+}
+
+// Blockers returns the dependencies that block this issue (not its parent)
+func (d *BeadsIssueDetails) Blockers() []BeadsIssueDependency {
+	return withoutParentChild(d.Dependencies)
+}
+
+// Blocked returns the dependents this issue blocks (not its children)
+func (d *BeadsIssueDetails) Blocked() []BeadsIssueDependency {
+	return withoutParentChild(d.Dependents)
+}
+
+// withoutParentChild drops parent-child links from a dependency list
+func withoutParentChild(deps []BeadsIssueDependency) []BeadsIssueDependency {
+	var result []BeadsIssueDependency
+	for _, dep := range deps {
+		if dep.DependencyType != beadsParentChild {
+			result = append(result, dep)
+		}
+	}
+	return result
 }
 
 // Column ID to status mapping
@@ -79,13 +163,34 @@ const (
 	ColDone       = "col-6" // closed
 )
 
+// Built-in beads statuses. Custom ones (bd config set status.custom) are
+// set with bd update --status like in_progress and blocked
+const (
+	StatusOpen       = "open"
+	StatusInProgress = "in_progress"
+	StatusBlocked    = "blocked"
+	StatusDeferred   = "deferred"
+	StatusClosed     = "closed"
+)
+
+// beadsBuiltinStatuses lists the built-in statuses in workflow order
+var beadsBuiltinStatuses = []string{StatusOpen, StatusInProgress, StatusBlocked, StatusDeferred, StatusClosed}
+
+// statusColumnPrefix starts the IDs of columns added for statuses that no
+// configured column shows
+const statusColumnPrefix = "status-"
+
 // NewBeadsBackend creates a new beads CLI backend
 func NewBeadsBackend() *BeadsBackend {
 	return &BeadsBackend{
-		cacheTTL: 5 * time.Second, // Refresh every 5 seconds
+		cacheTTL:   5 * time.Second, // Refresh every 5 seconds
+		issuesFile: beadsIssuesFile,
 	}
 }
 
+// errNoBeadsSource means neither bd nor the JSONL export could be read
+var errNoBeadsSource = errors.New("beads unavailable")
+
 // LoadBoard loads issues from beads and converts to Board format
 func (b *BeadsBackend) LoadBoard() (*Board, error) {
 	// Use cache if still valid
@@ -93,35 +198,61 @@ func (b *BeadsBackend) LoadBoard() (*Board, error) {
 		return b.cachedBoard, nil
 	}
 
-	// Run bd list --json (with --all if showing closed issues)
-	var cmd *exec.Cmd
-	if b.showAll {
-		cmd = exec.Command("bd", "list", "--all", "--json")
-	} else {
-		cmd = exec.Command("bd", "list", "--json")
-	}
-	output, err := cmd.Output()
-	if err != nil {
-		// If bd command fails, return empty board
+	issues, err := b.listIssues()
+	if errors.Is(err, errNoBeadsSource) {
+		// Neither bd nor the JSONL export, return empty board
 		if b.cachedBoard != nil {
 			return b.cachedBoard, nil
 		}
 		return b.createEmptyBoard(), nil
 	}
-
-	// Parse JSON
-	var issues []BeadsIssue
-	if err := json.Unmarshal(output, &issues); err != nil {
-		return nil, fmt.Errorf("failed to parse beads output: %w", err)
+	if err != nil {
+		return nil, err
 	}
 
-	// Create board with standard columns
+	// Create board with standard columns, plus one per custom status
 	board := b.createEmptyBoard()
+	for _, status := range b.CustomStatuses() {
+		statusColumn(board, status)
+	}
 
-	// Convert issues to tasks and assign to columns
+	// Convert issues to tasks and assign to columns (adding columns for
+	// statuses no column shows yet)
+	b.parents = make(map[string]string)
+	b.statuses = make(map[string]string)
+	b.loaded = make(map[string]beadsFields)
+	hasEpics := false
 	for _, issue := range issues {
-		task := b.issueToTask(&issue)
+		task := b.issueToTask(&issue, board)
 		board.Tasks = append(board.Tasks, task)
+		b.parents[task.ID] = task.Parent
+		b.loaded[task.ID] = issueFields(&issue)
+		b.statuses[task.ID] = issue.Status
+		hasEpics = hasEpics || isEpic(task)
+	}
+
+	b.columnStatus = make(map[string]string)
+	for _, col := range board.Columns {
+		b.columnStatus[col.ID] = col.Status
+	}
+
+	// Closed issues aren't listed, but still count towards epic progress
+	if hasEpics && !b.showAll {
+		closed := b.closedChildCounts()
+		for _, task := range board.Tasks {
+			task.HiddenChildren = closed[task.ID]
+		}
+	}
+
+	// Restore kanban-side task state (agent runs, history, chat sessions)
+	extras, err := loadBeadsTaskExtras()
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range board.Tasks {
+		if extra, ok := extras[task.ID]; ok {
+			extra.applyTo(task)
+		}
 	}
 
 	// Populate column tasks
@@ -134,61 +265,330 @@ func (b *BeadsBackend) LoadBoard() (*Board, error) {
 	return board, nil
 }
 
+// listIssues returns the issues to show from bd list --json (with --all if
+// showing closed issues), or from the JSONL export when bd is unavailable
+func (b *BeadsBackend) listIssues() ([]BeadsIssue, error) {
+	if !b.jsonlOnly {
+		var cmd *exec.Cmd
+		if b.showAll {
+			cmd = exec.Command("bd", "list", "--all", "--json")
+		} else {
+			cmd = exec.Command("bd", "list", "--json")
+		}
+		if output, err := cmd.Output(); err == nil {
+			var issues []BeadsIssue
+			if err := json.Unmarshal(output, &issues); err != nil {
+				return nil, fmt.Errorf("failed to parse beads output: %w", err)
+			}
+			b.source = beadsSourceBD
+			b.jsonlIssues = nil
+			return issues, nil
+		}
+	}
+
+	all, err := readBeadsJSONL(b.issuesFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errNoBeadsSource
+	}
+	if err != nil {
+		return nil, err
+	}
+	b.source = beadsSourceJSONL
+	b.jsonlIssues = all
+	return visibleIssues(all, b.showAll), nil
+}
+
+// closedChildCounts counts closed issues per parent epic
+func (b *BeadsBackend) closedChildCounts() map[string]int {
+	counts := make(map[string]int)
+	closed := b.jsonlIssues
+	if b.source == beadsSourceBD {
+		output, err := exec.Command("bd", "list", "--status", "closed", "--json").Output()
+		if err != nil || json.Unmarshal(output, &closed) != nil {
+			return counts
+		}
+	}
+	for _, issue := range closed {
+		if issue.Status != "closed" {
+			continue
+		}
+		if parent := issue.parentID(); parent != "" {
+			counts[parent]++
+		}
+	}
+	return counts
+}
+
 // createEmptyBoard creates a board with standard columns for beads workflow
 func (b *BeadsBackend) createEmptyBoard() *Board {
 	now := time.Now()
-	return &Board{
+	board := &Board{
 		ID:          "beads-board",
 		Name:        "Beads Issues",
 		Description: "Issues from beads tracker",
 		CreatedAt:   now,
 		UpdatedAt:   now,
-		Columns: []Column{
-			{ID: ColBacklog, Title: "Backlog", Color: "border-t-slate-500", Order: 0},
-			{ID: ColReady, Title: "Ready", Color: "border-t-cyan-500", Order: 1},
-			{ID: ColInProgress, Title: "In Progress", Color: "border-t-yellow-500", Order: 2},
-			{ID: ColAIWorking, Title: "AI Working", Color: "border-t-emerald-500", Order: 3, AssignedAgent: AgentClaudeCode},
-			{ID: ColReview, Title: "Review", Color: "border-t-pink-500", Order: 4},
-			{ID: ColDone, Title: "Done", Color: "border-t-green-500", Order: 5},
-		},
-		Tasks: []*Task{},
+		Columns:     beadsDefaultColumns(),
+		Tasks:       []*Task{},
+	}
+	applyBeadsColumnConfig(board)
+	return board
+}
+
+// beadsDefaultColumns returns the standard columns for the beads workflow
+func beadsDefaultColumns() []Column {
+	return []Column{
+		{ID: ColBacklog, Title: "Backlog", Color: "border-t-slate-500", Order: 0, Status: StatusOpen},
+		{ID: ColReady, Title: "Ready", Color: "border-t-cyan-500", Order: 1, Status: StatusOpen},
+		{ID: ColInProgress, Title: "In Progress", Color: "border-t-yellow-500", Order: 2, Status: StatusInProgress},
+		{ID: ColAIWorking, Title: "AI Working", Color: "border-t-emerald-500", Order: 3, AssignedAgent: AgentClaudeCode, Status: StatusInProgress},
+		{ID: ColReview, Title: "Review", Color: "border-t-pink-500", Order: 4, Status: StatusInProgress},
+		{ID: ColDone, Title: "Done", Color: "border-t-green-500", Order: 5, Status: StatusClosed},
+	}
+}
+
+// statusColumn returns the first column showing a status. When there is none,
+// a column whose title names the status and whose status an earlier column
+// already shows takes it over (Ready for a custom "ready" status); otherwise
+// a column is added before Done
+func statusColumn(board *Board, status string) *Column {
+	if status == "" {
+		status = StatusOpen
+	}
+	for i := range board.Columns {
+		if board.Columns[i].Status == status {
+			return &board.Columns[i]
+		}
+	}
+
+	title := statusTitle(status)
+	for i := range board.Columns {
+		col := &board.Columns[i]
+		if strings.EqualFold(col.Title, title) && statusShownBefore(board, i) {
+			col.Status = status
+			return col
+		}
+	}
+
+	color := "border-t-violet-500"
+	switch status {
+	case StatusBlocked:
+		color = "border-t-red-500"
+	case StatusDeferred:
+		color = "border-t-indigo-500"
+	}
+	return insertStatusColumn(board, Column{ID: statusColumnPrefix + status, Title: title, Color: color, Status: status})
+}
+
+// statusShownBefore reports whether a column before index shows its status
+func statusShownBefore(board *Board, index int) bool {
+	for i := 0; i < index; i++ {
+		if board.Columns[i].Status == board.Columns[index].Status {
+			return true
+		}
+	}
+	return false
+}
+
+// insertStatusColumn adds a column before the closed (Done) column
+func insertStatusColumn(board *Board, col Column) *Column {
+	at := len(board.Columns)
+	for i := range board.Columns {
+		if board.Columns[i].Status == StatusClosed {
+			at = i
+			break
+		}
+	}
+	if at < len(board.Columns) {
+		col.Order = board.Columns[at].Order
+	}
+	board.Columns = append(board.Columns, Column{})
+	copy(board.Columns[at+1:], board.Columns[at:])
+	board.Columns[at] = col
+	return &board.Columns[at]
+}
+
+// statusTitle turns a status into a column title: in_review -> In Review
+func statusTitle(status string) string {
+	words := strings.FieldsFunc(status, func(r rune) bool { return r == '_' || r == '-' || r == ' ' })
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// isAutoStatusColumn reports whether a column was added for a status and
+// carries no settings worth saving
+func isAutoStatusColumn(col Column) bool {
+	return strings.HasPrefix(col.ID, statusColumnPrefix) && col.WIPLimit == 0 && col.AssignedAgent == "" &&
+		col.Prompt == "" && col.AutoAdvance == nil && !col.IsCollapsed
+}
+
+// beadsColumnConfigFile stores kanban-side column settings (prompts, WIP limits,
+// agents, colors, auto-advance rules) that beads itself has no place for
+const beadsColumnConfigFile = ".beads/kanban-columns.yaml"
+
+// applyBeadsColumnConfig overlays saved column settings onto the board's columns
+func applyBeadsColumnConfig(board *Board) {
+	data, err := os.ReadFile(beadsColumnConfigFile)
+	if err != nil {
+		return
+	}
+
+	var saved []Column
+	if err := yaml.Unmarshal(data, &saved); err != nil {
+		return
+	}
+
+	for _, cfg := range saved {
+		col := findColumnByID(board, cfg.ID)
+		if col == nil {
+			// Extra columns show a (custom) status
+			if cfg.Status != "" {
+				cfg.Tasks = nil
+				insertStatusColumn(board, cfg)
+			}
+			continue
+		}
+		if cfg.Color != "" {
+			col.Color = cfg.Color
+		}
+		if cfg.Status != "" {
+			col.Status = cfg.Status
+		}
+		col.WIPLimit = cfg.WIPLimit
+		col.AssignedAgent = cfg.AssignedAgent
+		col.Prompt = cfg.Prompt
+		col.AutoAdvance = cfg.AutoAdvance
 	}
 }
 
-// issueToTask converts a BeadsIssue to a Task
-func (b *BeadsBackend) issueToTask(issue *BeadsIssue) *Task {
+// saveBeadsColumnConfig writes the board's column settings to the sidecar file
+// Nothing is written while the columns still match the defaults; columns added
+// for a status are only kept once they have settings of their own
+func saveBeadsColumnConfig(board *Board) error {
+	var columns []Column
+	for _, col := range board.Columns {
+		if isAutoStatusColumn(col) {
+			continue
+		}
+		col.Tasks = nil
+		columns = append(columns, col)
+	}
+
+	if _, err := os.Stat(beadsColumnConfigFile); os.IsNotExist(err) &&
+		reflect.DeepEqual(columns, beadsDefaultColumns()) {
+		return nil
+	}
+
+	data, err := yaml.Marshal(columns)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(beadsColumnConfigFile, data, 0644)
+}
+
+// beadsTaskExtrasFile stores kanban-side task state (agent runs, history,
+// chat sessions) that beads has no fields for
+const beadsTaskExtrasFile = ".beads/kanban-tasks.yaml"
+
+// beadsTaskExtras is the kanban-side state kept for one beads issue
+type beadsTaskExtras struct {
+	Agent    *AgentInfo     `yaml:"agent,omitempty"`
+	History  []HistoryEntry `yaml:"history,omitempty"`
+	Sessions []ChatSession  `yaml:"sessions,omitempty"`
+}
+
+// extrasFromTask returns the kanban-side state of a task
+func extrasFromTask(task *Task) beadsTaskExtras {
+	return beadsTaskExtras{Agent: task.Agent, History: task.History, Sessions: task.Sessions}
+}
+
+// applyTo copies the saved state onto a task loaded from beads
+func (e beadsTaskExtras) applyTo(task *Task) {
+	task.Agent = e.Agent
+	task.History = e.History
+	task.Sessions = e.Sessions
+}
+
+// isEmpty reports whether there is nothing worth saving
+func (e beadsTaskExtras) isEmpty() bool {
+	return e.Agent == nil && len(e.History) == 0 && len(e.Sessions) == 0
+}
+
+// loadBeadsTaskExtras reads the task sidecar file (empty if missing)
+func loadBeadsTaskExtras() (map[string]beadsTaskExtras, error) {
+	extras := make(map[string]beadsTaskExtras)
+	data, err := os.ReadFile(beadsTaskExtrasFile)
+	if os.IsNotExist(err) {
+		return extras, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, &extras); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", beadsTaskExtrasFile, err)
+	}
+	return extras, nil
+}
+
+// saveBeadsTaskExtras writes one task's kanban-side state to the sidecar file.
+// A file that doesn't parse is left alone rather than overwritten
+func saveBeadsTaskExtras(task *Task) error {
+	extras, err := loadBeadsTaskExtras()
+	if err != nil {
+		return err
+	}
+	extra := extrasFromTask(task)
+
+	if extra.isEmpty() {
+		if _, ok := extras[task.ID]; !ok {
+			return nil // Nothing saved before, nothing to save now
+		}
+		delete(extras, task.ID)
+	} else {
+		extras[task.ID] = extra
+	}
+
+	data, err := yaml.Marshal(extras)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(beadsTaskExtrasFile, data, 0644)
+}
+
+// issueToTask converts a BeadsIssue to a Task in the column showing its status
+func (b *BeadsBackend) issueToTask(issue *BeadsIssue, board *Board) *Task {
+	addIssueType(issue.IssueType)
 	return &Task{
 		ID:          issue.ID,
 		Title:       issue.Title,
 		Description: issue.Description,
-		ColumnID:    b.statusToColumnID(issue.Status),
+		ColumnID:    statusColumn(board, issue.Status).ID,
 		Priority:    b.beadsPriorityToPriority(issue.Priority),
-		Labels:      []string{issue.IssueType},
+		Labels:      append([]string{issue.IssueType}, issue.Labels...),
 		Assignee:    issue.Assignee,
+		Estimate:    formatEstimate(issue.Estimate),
+		DueDate:     formatDueDate(issue.DueAt),
 		CreatedAt:   issue.CreatedAt,
 		UpdatedAt:   issue.UpdatedAt,
 		BlockedBy:   issue.BlockedBy,
 		Blocking:    issue.Blocking,
 		IsReady:     issue.DependencyCount == 0 && issue.Status == "open",
-	}
-}
-
-// statusToColumnID maps beads status to column ID
-func (b *BeadsBackend) statusToColumnID(status string) string {
-	switch status {
-	case "open":
-		return ColBacklog
-	case "in_progress":
-		return ColInProgress
-	case "closed":
-		return ColDone
-	default:
-		return ColBacklog
+		Parent:      issue.parentID(),
+		Resolution:  issue.CloseReason,
+		ExternalID:  issue.ExternalRef,
 	}
 }
 
 // columnIDToStatus maps column ID to beads status
 func (b *BeadsBackend) columnIDToStatus(columnID string) string {
+	if status := b.columnStatus[columnID]; status != "" {
+		return status
+	}
+	if status, ok := strings.CutPrefix(columnID, statusColumnPrefix); ok {
+		return status // Added since the last load
+	}
 	switch columnID {
 	case ColBacklog, ColReady:
 		return "open"
@@ -233,66 +633,394 @@ func (b *BeadsBackend) priorityToBeadsPriority(p Priority) int {
 	}
 }
 
-// SaveBoard saves the board state - for beads, this is mostly a no-op
-// since individual operations update beads directly
+// SaveBoard saves the board state - for beads, only column settings are
+// written since individual operations update beads directly
 func (b *BeadsBackend) SaveBoard(board *Board) error {
 	// Update cache
 	b.cachedBoard = board
 	b.lastLoad = time.Now()
-	return nil
+
+	// Column settings live kanban-side
+	return saveBeadsColumnConfig(board)
 }
 
 // MoveTask moves a task to a different column by updating its beads status
 func (b *BeadsBackend) MoveTask(taskID string, toColumn string) error {
-	newStatus := b.columnIDToStatus(toColumn)
+	return b.SetStatus(taskID, b.columnIDToStatus(toColumn))
+}
 
-	// Use bd update to change status
-	if newStatus == "closed" {
-		// Use bd close for closing issues
-		cmd := exec.Command("bd", "close", taskID)
+// SetStatus changes an issue's status with the bd command that has the right
+// side effects: bd close and bd defer, bd reopen when leaving closed, and
+// bd update --status for everything else
+func (b *BeadsBackend) SetStatus(taskID, status string) error {
+	oldStatus := b.statuses[taskID]
+
+	switch {
+	case status == StatusClosed:
+		return b.CloseTask(taskID, "")
+	case status == StatusDeferred:
+		return b.DeferTask(taskID, "")
+	case oldStatus == StatusClosed:
+		// Reopening clears closed_at, which bd update --status would keep
+		cmd := exec.Command("bd", "reopen", taskID)
 		if err := cmd.Run(); err != nil {
-			return fmt.Errorf("failed to close issue %s: %w", taskID, err)
+			return fmt.Errorf("failed to reopen issue %s: %w", taskID, err)
 		}
-	} else {
-		// Use bd update for status changes
-		cmd := exec.Command("bd", "update", taskID, "--status", newStatus)
+		if status != StatusOpen {
+			cmd = exec.Command("bd", "update", taskID, "--status", status)
+			if err := cmd.Run(); err != nil {
+				return fmt.Errorf("failed to update issue %s status: %w", taskID, err)
+			}
+		}
+	default:
+		cmd := exec.Command("bd", "update", taskID, "--status", status)
 		if err := cmd.Run(); err != nil {
 			return fmt.Errorf("failed to update issue %s status: %w", taskID, err)
 		}
 	}
 
-	// Invalidate cache
-	b.cachedBoard = nil
+	b.setKnownStatus(taskID, status)
+	return nil
+}
 
+// DeferTask defers an issue, until a date (YYYY-MM-DD) when given
+func (b *BeadsBackend) DeferTask(taskID, until string) error {
+	args := []string{"defer", taskID}
+	if until != "" {
+		args = append(args, "--until", until)
+	}
+	cmd := exec.Command("bd", args...)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to defer issue %s: %w", taskID, err)
+	}
+	b.setKnownStatus(taskID, StatusDeferred)
 	return nil
 }
 
-// UpdateTask updates a task's details
+// setKnownStatus records a status change and invalidates the cache
+func (b *BeadsBackend) setKnownStatus(taskID, status string) {
+	if b.statuses == nil {
+		b.statuses = make(map[string]string)
+	}
+	b.statuses[taskID] = status
+	b.cachedBoard = nil
+}
+
+// CustomStatuses returns the statuses configured with bd config set
+// status.custom (read once; none when bd is unavailable)
+func (b *BeadsBackend) CustomStatuses() []string {
+	if b.customLoaded || b.jsonlOnly {
+		return b.customStatuses
+	}
+	b.customLoaded = true
+
+	output, err := exec.Command("bd", "config", "get", "status.custom").Output()
+	if err != nil {
+		return nil
+	}
+	b.customStatuses = parseCustomStatuses(string(output))
+	return b.customStatuses
+}
+
+// parseCustomStatuses reads "a,b,c" (or "status.custom = a,b,c") from bd config get
+func parseCustomStatuses(output string) []string {
+	value := strings.TrimSpace(output)
+	if i := strings.LastIndexAny(value, "=:"); i >= 0 {
+		value = value[i+1:]
+	}
+	var statuses []string
+	for _, status := range strings.Split(value, ",") {
+		status = strings.Trim(strings.TrimSpace(status), `"'`)
+		if status != "" && !strings.Contains(status, " ") && !containsString(beadsBuiltinStatuses, status) {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+// UpdateTask writes the fields changed since the issue was last loaded
+// (title, description, type, priority, assignee, estimate), its labels
+// (bd label add/remove) and its parent epic. bd's own message is returned
+// when it rejects a change
 func (b *BeadsBackend) UpdateTask(task *Task) error {
-	// Build update command with changed fields
-	args := []string{"update", task.ID}
+	fields, err := b.taskFields(task)
+	if err != nil {
+		return fmt.Errorf("%s: %w", task.ID, err)
+	}
 
-	// Update title if provided
-	if task.Title != "" {
-		args = append(args, "--title", task.Title)
+	old, known := b.loaded[task.ID]
+	if !known {
+		old = beadsFields{priority: -1} // Not loaded from beads: send everything set
 	}
 
-	// Update priority
-	args = append(args, "--priority", strconv.Itoa(b.priorityToBeadsPriority(task.Priority)))
+	if args, changed := fields.updateArgs(old); len(changed) > 0 {
+		if err := runBD(append([]string{"update", task.ID}, args...)...); err != nil {
+			return fmt.Errorf("beads rejected the %s change to %s: %w", strings.Join(changed, ", "), task.ID, err)
+		}
+		written := fields
+		written.labels, written.blockedBy = old.labels, old.blockedBy
+		b.rememberFields(task.ID, written)
+		b.cachedBoard = nil
+	}
 
-	// Update assignee if provided
-	if task.Assignee != "" {
-		args = append(args, "--assignee", task.Assignee)
+	add, remove := listChanges(fields.labels, old.labels)
+	for _, label := range add {
+		if err := runBD("label", "add", task.ID, label); err != nil {
+			return fmt.Errorf("beads rejected label %s on %s: %w", label, task.ID, err)
+		}
+		b.rememberLabel(task.ID, label, true)
+	}
+	for _, label := range remove {
+		if err := runBD("label", "remove", task.ID, label); err != nil {
+			return fmt.Errorf("beads rejected removing label %s from %s: %w", label, task.ID, err)
+		}
+		b.rememberLabel(task.ID, label, false)
+	}
+
+	add, remove = listChanges(fields.blockedBy, old.blockedBy)
+	for _, blocker := range add {
+		if err := runBD("dep", "add", task.ID, blocker); err != nil {
+			return fmt.Errorf("beads rejected %s blocking %s: %w", blocker, task.ID, err)
+		}
+		b.rememberBlocker(task.ID, blocker, true)
+	}
+	for _, blocker := range remove {
+		if err := runBD("dep", "remove", task.ID, blocker); err != nil {
+			return fmt.Errorf("beads rejected unblocking %s from %s: %w", task.ID, blocker, err)
+		}
+		b.rememberBlocker(task.ID, blocker, false)
+	}
+
+	if err := b.updateParent(task); err != nil {
+		return err
 	}
 
+	return saveBeadsTaskExtras(task)
+}
+
+// beadsFields are the issue fields UpdateTask keeps in sync
+type beadsFields struct {
+	title       string
+	description string
+	issueType   string
+	priority    int
+	assignee    string
+	labels      []string
+	blockedBy   []string
+	estimate    int    // Minutes, 0 for none
+	due         string // YYYY-MM-DD, "" for none
+	externalRef string
+}
+
+// issueFields returns an issue's editable fields
+func issueFields(issue *BeadsIssue) beadsFields {
+	fields := beadsFields{
+		title:       issue.Title,
+		description: issue.Description,
+		issueType:   issue.IssueType,
+		priority:    issue.Priority,
+		assignee:    issue.Assignee,
+		labels:      append([]string(nil), issue.Labels...),
+		blockedBy:   append([]string(nil), issue.BlockedBy...),
+		due:         formatDueDate(issue.DueAt),
+		externalRef: issue.ExternalRef,
+	}
+	if issue.Estimate != nil {
+		fields.estimate = *issue.Estimate
+	}
+	return fields
+}
+
+// taskFields returns the issue fields a task maps to: the first label is the
+// issue type, the rest are beads labels
+func (b *BeadsBackend) taskFields(task *Task) (beadsFields, error) {
+	estimate, err := parseEstimate(task.Estimate)
+	if err != nil {
+		return beadsFields{}, err
+	}
+	fields := beadsFields{
+		title:       task.Title,
+		description: task.Description,
+		priority:    b.priorityToBeadsPriority(task.Priority),
+		assignee:    task.Assignee,
+		blockedBy:   append([]string(nil), task.BlockedBy...),
+		estimate:    estimate,
+		due:         task.DueDate,
+		externalRef: task.ExternalID,
+	}
+	if len(task.Labels) > 0 {
+		fields.issueType = task.Labels[0]
+		fields.labels = append([]string(nil), task.Labels[1:]...)
+	}
+	return fields, nil
+}
+
+// updateArgs returns the bd update flags turning old into f, and the names
+// of the changed fields
+func (f beadsFields) updateArgs(old beadsFields) (args, changed []string) {
+	if f.title != old.title && f.title != "" {
+		args = append(args, "--title", f.title)
+		changed = append(changed, "title")
+	}
+	if f.description != old.description {
+		args = append(args, "--description", f.description)
+		changed = append(changed, "description")
+	}
+	if f.issueType != old.issueType && f.issueType != "" {
+		args = append(args, "--type", f.issueType)
+		changed = append(changed, "type")
+	}
+	if f.priority != old.priority {
+		args = append(args, "--priority", strconv.Itoa(f.priority))
+		changed = append(changed, "priority")
+	}
+	if f.assignee != old.assignee {
+		args = append(args, "--assignee", f.assignee)
+		changed = append(changed, "assignee")
+	}
+	if f.estimate != old.estimate {
+		args = append(args, "--estimate", strconv.Itoa(f.estimate))
+		changed = append(changed, "estimate")
+	}
+	if f.due != old.due {
+		args = append(args, "--due", f.due)
+		changed = append(changed, "due date")
+	}
+	if f.externalRef != old.externalRef {
+		args = append(args, "--external-ref", f.externalRef)
+		changed = append(changed, "external ref")
+	}
+	return args, changed
+}
+
+// listChanges returns the items to add and remove to turn have into want
+func listChanges(want, have []string) (add, remove []string) {
+	for _, item := range want {
+		if !containsString(have, item) {
+			add = append(add, item)
+		}
+	}
+	for _, item := range have {
+		if !containsString(want, item) {
+			remove = append(remove, item)
+		}
+	}
+	return add, remove
+}
+
+// rememberFields records the fields beads now has for an issue
+func (b *BeadsBackend) rememberFields(issueID string, fields beadsFields) {
+	if b.loaded == nil {
+		b.loaded = make(map[string]beadsFields)
+	}
+	b.loaded[issueID] = fields
+}
+
+// rememberLabel records a label beads added to or removed from an issue
+func (b *BeadsBackend) rememberLabel(issueID, label string, added bool) {
+	fields := b.loaded[issueID]
+	fields.labels = toggleItem(fields.labels, label, added)
+	b.rememberFields(issueID, fields)
+	b.cachedBoard = nil
+}
+
+// rememberBlocker records a blocker beads added to or removed from an issue
+func (b *BeadsBackend) rememberBlocker(issueID, blocker string, added bool) {
+	fields := b.loaded[issueID]
+	fields.blockedBy = toggleItem(fields.blockedBy, blocker, added)
+	b.rememberFields(issueID, fields)
+	b.cachedBoard = nil
+}
+
+// toggleItem returns list without item, with it appended when added
+func toggleItem(list []string, item string, added bool) []string {
+	var result []string
+	for _, l := range list {
+		if l != item {
+			result = append(result, l)
+		}
+	}
+	if added {
+		result = append(result, item)
+	}
+	return result
+}
+
+// runBD runs a bd command, returning bd's own message when it fails
+func runBD(args ...string) error {
+	var stderr bytes.Buffer
 	cmd := exec.Command("bd", args...)
+	cmd.Stderr = &stderr
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to update issue %s: %w", task.ID, err)
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return errors.New(msg)
+		}
+		return err
 	}
+	return nil
+}
 
-	// Invalidate cache
-	b.cachedBoard = nil
+// formatEstimate renders beads' estimated minutes as 45m, 2h or 1h30m
+func formatEstimate(minutes *int) string {
+	if minutes == nil || *minutes <= 0 {
+		return ""
+	}
+	h, m := *minutes/60, *minutes%60
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%dm", m)
+	case m == 0:
+		return fmt.Sprintf("%dh", h)
+	}
+	return fmt.Sprintf("%dh%dm", h, m)
+}
+
+// formatDueDate renders beads' due time as YYYY-MM-DD
+func formatDueDate(due *time.Time) string {
+	if due == nil || due.IsZero() {
+		return ""
+	}
+	return due.Format("2006-01-02")
+}
+
+// parseEstimate reads an estimate as minutes ("90") or a duration ("1h30m")
+func parseEstimate(estimate string) (int, error) {
+	estimate = strings.TrimSpace(estimate)
+	if estimate == "" {
+		return 0, nil
+	}
+	if minutes, err := strconv.Atoi(estimate); err == nil && minutes >= 0 {
+		return minutes, nil
+	}
+	d, err := time.ParseDuration(estimate)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("estimate %q is not minutes or a duration like 1h30m", estimate)
+	}
+	return int(d.Round(time.Minute) / time.Minute), nil
+}
 
+// updateParent moves the issue's parent-child dependency to task.Parent
+func (b *BeadsBackend) updateParent(task *Task) error {
+	oldParent := b.parents[task.ID]
+	if oldParent == task.Parent {
+		return nil
+	}
+	if oldParent != "" {
+		cmd := exec.Command("bd", "dep", "remove", task.ID, oldParent)
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("failed to detach %s from %s: %w", task.ID, oldParent, err)
+		}
+	}
+	if task.Parent != "" {
+		cmd := exec.Command("bd", "dep", "add", task.ID, task.Parent, "--type", beadsParentChild)
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("failed to attach %s to %s: %w", task.ID, task.Parent, err)
+		}
+	}
+	if b.parents == nil {
+		b.parents = make(map[string]string)
+	}
+	b.parents[task.ID] = task.Parent
 	return nil
 }
 
@@ -336,20 +1064,17 @@ func (b *BeadsBackend) CreateTask(title, description, columnID, issueType string
 		issueID = fmt.Sprintf("task-%d", time.Now().UnixNano())
 	}
 
-	// If created in a non-Backlog column, update status
-	if columnID != ColBacklog && columnID != ColReady {
-		status := b.columnIDToStatus(columnID)
-		if status != "open" {
-			updateCmd := exec.Command("bd", "update", issueID, "--status", status)
-			_ = updateCmd.Run() // Best effort
-		}
+	// If created in a column for another status, update status
+	b.setKnownStatus(issueID, StatusOpen)
+	if status := b.columnIDToStatus(columnID); status != StatusOpen {
+		_ = b.SetStatus(issueID, status) // Best effort
 	}
 
 	// Invalidate cache
 	b.cachedBoard = nil
 
 	now := time.Now()
-	return &Task{
+	task := &Task{
 		ID:          issueID,
 		Title:       title,
 		Description: description,
@@ -359,28 +1084,44 @@ func (b *BeadsBackend) CreateTask(title, description, columnID, issueType string
 		CreatedAt:   now,
 		UpdatedAt:   now,
 		IsReady:     true,
-	}, nil
+	}
+	fields, _ := b.taskFields(task)
+	b.rememberFields(task.ID, fields)
+	return task, nil
 }
 
 // DeleteTask closes a beads issue (beads doesn't support hard delete)
 func (b *BeadsBackend) DeleteTask(taskID string) error {
 	// Close the issue instead of deleting
-	cmd := exec.Command("bd", "close", taskID, "--reason", "Closed from TUI")
-	if err := cmd.Run(); err != nil {
+	return b.CloseTask(taskID, resolutionKinds[resolutionObsolete].label)
+}
+
+// CloseTask closes an issue, recording the resolution as its close reason
+func (b *BeadsBackend) CloseTask(taskID, reason string) error {
+	args := []string{"close", taskID}
+	if reason != "" {
+		args = append(args, "--reason", reason)
+	}
+	if err := runBD(args...); err != nil {
 		return fmt.Errorf("failed to close issue %s: %w", taskID, err)
 	}
-
-	// Invalidate cache
-	b.cachedBoard = nil
-
+	b.setKnownStatus(taskID, StatusClosed)
 	return nil
 }
 
 // GetIssueDetails fetches full issue details including dependencies using bd show --json
+// When reading the JSONL export, or when bd show fails, details come from the export
 func (b *BeadsBackend) GetIssueDetails(issueID string) (*BeadsIssueDetails, error) {
+	if b.source == beadsSourceJSONL {
+		return jsonlIssueDetails(b.jsonlIssues, issueID)
+	}
+
 	cmd := exec.Command("bd", "show", issueID, "--json")
 	output, err := cmd.Output()
 	if err != nil {
+		if issues, jsonlErr := readBeadsJSONL(b.issuesFile); jsonlErr == nil {
+			return jsonlIssueDetails(issues, issueID)
+		}
 		return nil, fmt.Errorf("failed to get issue details for %s: %w", issueID, err)
 	}
 
@@ -394,7 +1135,37 @@ func (b *BeadsBackend) GetIssueDetails(issueID string) (*BeadsIssueDetails, erro
 		return nil, fmt.Errorf("no issue found with ID %s", issueID)
 	}
 
-	return &issues[0], nil
+	// Older bd show output has no comments
+	details := &issues[0]
+	if details.Comments == nil {
+		details.Comments = b.listComments(issueID)
+	}
+	return details, nil
+}
+
+// listComments returns an issue's comments from bd comments (none on error)
+func (b *BeadsBackend) listComments(issueID string) []BeadsComment {
+	output, err := exec.Command("bd", "comments", issueID, "--json").Output()
+	if err != nil {
+		return nil
+	}
+	var comments []BeadsComment
+	json.Unmarshal(output, &comments)
+	return comments
+}
+
+// AddComment posts a comment on an issue, as the comment's author when set
+func (b *BeadsBackend) AddComment(issueID string, comment Comment) error {
+	var args []string
+	if comment.Author != "" {
+		args = append(args, "--actor", comment.Author)
+	}
+	args = append(args, "comment", "--", issueID, comment.Text)
+	if err := runBD(args...); err != nil {
+		return fmt.Errorf("failed to comment on %s: %w", issueID, err)
+	}
+	b.cachedBoard = nil
+	return nil
 }
 
 // ToggleShowAll toggles showing closed issues and invalidates cache
@@ -404,6 +1175,11 @@ func (b *BeadsBackend) ToggleShowAll() bool {
 	return b.showAll
 }
 
+// Source returns where issues were last read from: beadsSourceBD or beadsSourceJSONL
+func (b *BeadsBackend) Source() string {
+	return b.source
+}
+
 // ShowingAll returns whether closed issues are being shown
 func (b *BeadsBackend) ShowingAll() bool {
 	return b.showAll
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeBD puts a bd script on PATH that logs its arguments and rejects
+// --type, returning the log file
+func fakeBD(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	log := filepath.Join(dir, "bd.log")
+	script := "#!/bin/sh\necho \"$@\" >> " + log + "\n" +
+		"case \"$*\" in *--type*) echo 'Error: invalid issue type' >&2; exit 1;; esac\n"
+	if err := os.WriteFile(filepath.Join(dir, "bd"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+	return log
+}
+
+func TestBeadsUpdateTaskSendsChangedFields(t *testing.T) {
+	b := jsonlBackend()
+	board, err := b.LoadBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := fakeBD(t)
+	task := findTaskByID(board, "kb-2")
+
+	if err := b.UpdateTask(task); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(log); err == nil {
+		t.Error("unchanged task ran bd")
+	}
+
+	task.Description = "Rank by relevance"
+	task.Labels = []string{"feature", "backend", "ux"}
+	task.Estimate = "1h30m"
+	if err := b.UpdateTask(task); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(log)
+	want := "update kb-2 --description Rank by relevance --estimate 90\nlabel add kb-2 ux\nlabel remove kb-2 perf\n"
+	if string(data) != want {
+		t.Errorf("bd got %q, want %q", data, want)
+	}
+
+	// Written fields aren't sent again
+	os.Remove(log)
+	if err := b.UpdateTask(task); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(log); err == nil {
+		t.Error("fields already written were sent again")
+	}
+
+	task.Labels[0] = "chore"
+	err = b.UpdateTask(task)
+	if err == nil || !strings.Contains(err.Error(), "type") || !strings.Contains(err.Error(), "invalid issue type") {
+		t.Errorf("rejected type change returned %v, want the field and bd's message", err)
+	}
+
+	task.Estimate = "soon"
+	if err := b.UpdateTask(task); err == nil {
+		t.Error("unparseable estimate was accepted")
+	}
+}
+
+func TestEstimateRoundTrip(t *testing.T) {
+	for _, minutes := range []int{45, 120, 90} {
+		text := formatEstimate(&minutes)
+		got, err := parseEstimate(text)
+		if err != nil || got != minutes {
+			t.Errorf("%d minutes formatted as %q parsed back as %d, %v", minutes, text, got, err)
+		}
+	}
+	if got, _ := parseEstimate("30"); got != 30 {
+		t.Errorf("plain number parsed as %d minutes, want 30", got)
+	}
+}
+
+func TestBeadsTaskExtrasKeepsUnparseableFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.Mkdir(".beads", 0755); err != nil {
+		t.Fatal(err)
+	}
+	corrupt := "kb-1: [sessions\n"
+	if err := os.WriteFile(beadsTaskExtrasFile, []byte(corrupt), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := saveBeadsTaskExtras(&Task{ID: "kb-2", Agent: &AgentInfo{Status: AgentRunning}}); err == nil {
+		t.Error("saved on top of a file that doesn't parse")
+	}
+	if data, _ := os.ReadFile(beadsTaskExtrasFile); string(data) != corrupt {
+		t.Errorf("sidecar rewritten to %q", data)
+	}
+}
+
+func TestBeadsAddCommentWithLeadingDash(t *testing.T) {
+	log := fakeBD(t)
+	comment := Comment{Author: "claude-code", Text: "- fixed the ranking\n- added tests"}
+	if err := jsonlBackend().AddComment("kb-2", comment); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(log)
+	if want := "--actor claude-code comment -- kb-2 " + comment.Text + "\n"; string(data) != want {
+		t.Errorf("bd got %q, want %q", data, want)
+	}
+}
+
+func TestBeadsKeepsCustomIssueTypes(t *testing.T) {
+	defer func(types []string) { issueTypes = types }(append([]string(nil), issueTypes...))
+	b := jsonlBackend()
+	board, err := b.LoadBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	task := b.issueToTask(&BeadsIssue{ID: "kb-9", Title: "Pick a DB", Status: StatusOpen, IssueType: "decision", Labels: []string{"arch"}}, board)
+	if taskIssueType(task) != "decision" || strings.Join(taskLabels(task), ",") != "arch" {
+		t.Fatalf("decision loaded as type %q, labels %v", taskIssueType(task), taskLabels(task))
+	}
+	setTaskLabels(task, []string{"arch", "db"})
+	if strings.Join(task.Labels, ",") != "decision,arch,db" {
+		t.Errorf("relabelled to %v", task.Labels)
+	}
+}
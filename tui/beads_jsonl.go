@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// beads_jsonl.go - Reading beads issues straight from .beads/issues.jsonl
+// beads keeps a JSONL export of its database next to it. Reading it needs no
+// bd binary, so it is the fallback when bd is missing or fails, and the fast
+// path with --beads-jsonl. Writes still go through bd
+
+// beadsIssuesFile is the JSONL export beads keeps in the project
+const beadsIssuesFile = ".beads/issues.jsonl"
+
+// Where the beads backend last read its issues from
+const (
+	beadsSourceBD    = "bd"
+	beadsSourceJSONL = "jsonl"
+)
+
+// jsonlMaxLine is the longest JSONL record accepted (long descriptions)
+const jsonlMaxLine = 16 * 1024 * 1024
+
+// readBeadsJSONL parses a beads JSONL export. A record repeated later in the
+// file replaces the earlier one; deleted (tombstone) issues are dropped.
+// Counts and blocker lists that bd list computes are derived from the
+// dependency records
+func readBeadsJSONL(path string) ([]BeadsIssue, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var issues []BeadsIssue
+	index := make(map[string]int)
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), jsonlMaxLine)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var issue BeadsIssue
+		if err := json.Unmarshal(line, &issue); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNum, err)
+		}
+		if i, ok := index[issue.ID]; ok {
+			issues[i] = issue
+			continue
+		}
+		index[issue.ID] = len(issues)
+		issues = append(issues, issue)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	live := issues[:0]
+	for _, issue := range issues {
+		if issue.Status != "tombstone" {
+			live = append(live, issue)
+		}
+	}
+	deriveDependencyFields(live)
+	return live, nil
+}
+
+// deriveDependencyFields fills the dependency counts and open blocker lists
+func deriveDependencyFields(issues []BeadsIssue) {
+	status := make(map[string]string, len(issues))
+	byID := make(map[string]*BeadsIssue, len(issues))
+	for i := range issues {
+		status[issues[i].ID] = issues[i].Status
+		byID[issues[i].ID] = &issues[i]
+	}
+
+	for i := range issues {
+		issue := &issues[i]
+		issue.DependencyCount = len(issue.Dependencies)
+		for _, dep := range issue.Dependencies {
+			target, ok := byID[dep.DependsOnID]
+			if !ok {
+				continue
+			}
+			target.DependentCount++
+			if dep.Type == "blocks" && status[dep.DependsOnID] != "closed" && issue.Status != "closed" {
+				issue.BlockedBy = append(issue.BlockedBy, dep.DependsOnID)
+				target.Blocking = append(target.Blocking, issue.ID)
+			}
+		}
+	}
+}
+
+// visibleIssues drops closed issues unless all are shown, like bd list
+func visibleIssues(issues []BeadsIssue, showAll bool) []BeadsIssue {
+	if showAll {
+		return issues
+	}
+	var visible []BeadsIssue
+	for _, issue := range issues {
+		if issue.Status != "closed" {
+			visible = append(visible, issue)
+		}
+	}
+	return visible
+}
+
+// jsonlIssueDetails builds what bd show --json would return for an issue
+func jsonlIssueDetails(issues []BeadsIssue, issueID string) (*BeadsIssueDetails, error) {
+	byID := make(map[string]*BeadsIssue, len(issues))
+	for i := range issues {
+		byID[issues[i].ID] = &issues[i]
+	}
+	issue, ok := byID[issueID]
+	if !ok {
+		return nil, fmt.Errorf("no issue found with ID %s", issueID)
+	}
+
+	details := &BeadsIssueDetails{
+		ID:          issue.ID,
+		Title:       issue.Title,
+		Description: issue.Description,
+		Status:      issue.Status,
+		Priority:    issue.Priority,
+		IssueType:   issue.IssueType,
+		CreatedAt:   issue.CreatedAt,
+		CreatedBy:   issue.CreatedBy,
+		UpdatedAt:   issue.UpdatedAt,
+		ClosedAt:    issue.ClosedAt,
+		CloseReason: issue.CloseReason,
+		Assignee:    issue.Assignee,
+		Labels:      issue.Labels,
+		Comments:    issue.Comments,
+	}
+
+	for _, dep := range issue.Dependencies {
+		if target, ok := byID[dep.DependsOnID]; ok {
+			details.Dependencies = append(details.Dependencies, issueAsDependency(target, dep.Type))
+		}
+	}
+	for i := range issues {
+		for _, dep := range issues[i].Dependencies {
+			if dep.DependsOnID == issueID {
+				details.Dependents = append(details.Dependents, issueAsDependency(&issues[i], dep.Type))
+			}
+		}
+	}
+	return details, nil
+}
+
+// issueAsDependency converts an issue to an entry of a details dependency list
+func issueAsDependency(issue *BeadsIssue, depType string) BeadsIssueDependency {
+	return BeadsIssueDependency{
+		ID:             issue.ID,
+		Title:          issue.Title,
+		Description:    issue.Description,
+		Status:         issue.Status,
+		Priority:       issue.Priority,
+		IssueType:      issue.IssueType,
+		CreatedAt:      issue.CreatedAt,
+		CreatedBy:      issue.CreatedBy,
+		UpdatedAt:      issue.UpdatedAt,
+		ClosedAt:       issue.ClosedAt,
+		DependencyType: depType,
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const fixtureIssues = "testdata/issues.jsonl"
+
+// jsonlBackend returns a beads backend that reads the fixture without bd
+func jsonlBackend() *BeadsBackend {
+	b := NewBeadsBackend()
+	b.issuesFile = fixtureIssues
+	b.jsonlOnly = true
+	return b
+}
+
+func TestReadBeadsJSONL(t *testing.T) {
+	issues, err := readBeadsJSONL(fixtureIssues)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	byID := make(map[string]BeadsIssue)
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+		byID[issue.ID] = issue
+	}
+	if got := strings.Join(ids, " "); got != "kb-epic kb-1 kb-2 kb-3" {
+		t.Fatalf("issues = %s, want the tombstone dropped and kb-3 once", got)
+	}
+
+	if got := byID["kb-3"].Title; got != "Search UI (renamed)" {
+		t.Errorf("kb-3 title = %q, want the later record", got)
+	}
+	closed := byID["kb-1"]
+	if closed.CloseReason != "Shipped in v2" || closed.ClosedAt.IsZero() {
+		t.Errorf("kb-1 close = %q at %v, want reason and time", closed.CloseReason, closed.ClosedAt)
+	}
+	if got := strings.Join(byID["kb-2"].Labels, ","); got != "backend,perf" {
+		t.Errorf("kb-2 labels = %s", got)
+	}
+	if got := byID["kb-2"].parentID(); got != "kb-epic" {
+		t.Errorf("kb-2 parent = %q, want kb-epic", got)
+	}
+
+	// Only open blockers count; kb-1 is closed
+	if len(byID["kb-2"].BlockedBy) != 0 {
+		t.Errorf("kb-2 blocked by %v, want nothing", byID["kb-2"].BlockedBy)
+	}
+	if got := strings.Join(byID["kb-3"].BlockedBy, ","); got != "kb-2" {
+		t.Errorf("kb-3 blocked by %s, want kb-2", got)
+	}
+	if got := strings.Join(byID["kb-2"].Blocking, ","); got != "kb-3" {
+		t.Errorf("kb-2 blocking %s, want kb-3", got)
+	}
+	if byID["kb-2"].DependencyCount != 2 || byID["kb-epic"].DependentCount != 2 {
+		t.Errorf("counts = %d/%d, want 2/2", byID["kb-2"].DependencyCount, byID["kb-epic"].DependentCount)
+	}
+}
+
+func TestReadBeadsJSONLReportsBadLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	os.WriteFile(path, []byte("{\"id\":\"a\",\"status\":\"open\"}\n<<<<<<< HEAD\n"), 0644)
+
+	_, err := readBeadsJSONL(path)
+	if err == nil || !strings.Contains(err.Error(), ":2:") {
+		t.Errorf("err = %v, want it to name line 2", err)
+	}
+}
+
+func TestBeadsBackendLoadsFromJSONL(t *testing.T) {
+	b := jsonlBackend()
+	board, err := b.LoadBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Source() != beadsSourceJSONL {
+		t.Errorf("source = %q, want jsonl", b.Source())
+	}
+
+	if findTaskByID(board, "kb-1") != nil {
+		t.Error("closed kb-1 is on the board without show all")
+	}
+	task := findTaskByID(board, "kb-2")
+	if task == nil || task.ColumnID != ColInProgress || task.Parent != "kb-epic" || task.Priority != PriorityUrgent {
+		t.Fatalf("kb-2 = %+v", task)
+	}
+	if epic := findTaskByID(board, "kb-epic"); epic == nil || epic.HiddenChildren != 1 {
+		t.Errorf("epic = %+v, want one hidden closed child", epic)
+	}
+
+	b.ToggleShowAll()
+	board, err = b.LoadBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task := findTaskByID(board, "kb-1"); task == nil || task.ColumnID != ColDone {
+		t.Errorf("kb-1 = %+v, want it in Done with show all", task)
+	}
+}
+
+func TestBeadsBackendFallsBackWithoutBd(t *testing.T) {
+	t.Setenv("PATH", t.TempDir()) // No bd binary
+
+	b := jsonlBackend()
+	b.jsonlOnly = false
+	board, err := b.LoadBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Source() != beadsSourceJSONL || findTaskByID(board, "kb-3") == nil {
+		t.Errorf("source = %q, want the board read from the JSONL export", b.Source())
+	}
+
+	b = NewBeadsBackend()
+	b.issuesFile = filepath.Join(t.TempDir(), "missing.jsonl")
+	board, err = b.LoadBoard()
+	if err != nil || len(board.Tasks) != 0 {
+		t.Errorf("without bd or export got %v tasks, err %v; want an empty board", len(board.Tasks), err)
+	}
+}
+
+func TestJSONLIssueDetails(t *testing.T) {
+	b := jsonlBackend()
+	if _, err := b.LoadBoard(); err != nil {
+		t.Fatal(err)
+	}
+
+	details, err := b.GetIssueDetails("kb-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(details.Dependencies) != 2 || len(details.Blockers()) != 1 || details.Blockers()[0].Title != "Index titles" {
+		t.Errorf("dependencies = %+v, want the epic and blocker kb-1", details.Dependencies)
+	}
+	if len(details.Blocked()) != 1 || details.Blocked()[0].ID != "kb-3" {
+		t.Errorf("dependents = %+v, want kb-3", details.Dependents)
+	}
+
+	epic, err := b.GetIssueDetails("kb-epic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(epic.Dependents) != 2 || len(epic.Blocked()) != 0 {
+		t.Errorf("epic dependents = %+v, want two children and no blocked issues", epic.Dependents)
+	}
+
+	if _, err := b.GetIssueDetails("kb-nope"); err == nil {
+		t.Error("unknown issue returned details")
+	}
+}
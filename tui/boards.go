@@ -0,0 +1,305 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textinput"
+)
+
+// boards.go - Multiple board discovery and the board switcher
+// Finds every board file in the boards directory (plus the beads project)
+// and remembers selection/filter state per board
+
+// beadsBoardKey identifies the beads project in the switcher and state map
+const beadsBoardKey = "beads"
+
+// BoardEntry describes a board that can be opened from the switcher
+type BoardEntry struct {
+	Key       string // File path, or beadsBoardKey for the beads project
+	Name      string // Board name (from the file) or file name
+	Path      string // File path (empty for beads)
+	IsBeads   bool
+	TaskCount int // -1 when not counted (beads without an issues.jsonl)
+}
+
+// boardViewState is the per-board UI state restored when switching back to a board
+type boardViewState struct {
+	selectedColumn     int
+	selectedTask       int
+	filterText         string
+	epicFocus          string
+	visibleColumnStart int
+	columnScrollOffset map[int]int
+	laneMode           LaneGrouping
+	selectedLane       int
+	collapsedLanes     map[string]bool
+}
+
+// isBoardFileExt reports whether a file extension can hold a board
+func isBoardFileExt(ext string) bool {
+	switch strings.ToLower(ext) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+// discoverBoards lists board files in dir plus the beads project if present
+// Files that parse but have no columns (other YAML/JSON files) are skipped
+func discoverBoards(dir string, includeBeads bool) []BoardEntry {
+	var entries []BoardEntry
+
+	files, err := os.ReadDir(dir)
+	if err == nil {
+		for _, f := range files {
+			if f.IsDir() || strings.HasPrefix(f.Name(), ".") || !isBoardFileExt(filepath.Ext(f.Name())) {
+				continue
+			}
+
+			path := filepath.Join(dir, f.Name())
+			board, err := NewLocalBackend(path).LoadBoard()
+			if err != nil || len(board.Columns) == 0 {
+				continue
+			}
+
+			name := board.Name
+			if name == "" {
+				name = strings.TrimSuffix(f.Name(), filepath.Ext(f.Name()))
+			}
+			entries = append(entries, BoardEntry{
+				Key:       path,
+				Name:      name,
+				Path:      path,
+				TaskCount: len(board.Tasks),
+			})
+		}
+	}
+
+	// Open issues are counted from the JSONL export, running bd would be too slow
+	if includeBeads {
+		entry := BoardEntry{Key: beadsBoardKey, Name: "Beads Issues", IsBeads: true, TaskCount: -1}
+		if issues, err := readBeadsJSONL(beadsIssuesFile); err == nil {
+			entry.TaskCount = len(visibleIssues(issues, false))
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// fuzzyScore matches pattern as a case-insensitive subsequence of text
+// Returns false if it doesn't match; higher scores are better matches
+// (consecutive runs and word-start hits score extra)
+func fuzzyScore(pattern, text string) (int, bool) {
+	if pattern == "" {
+		return 0, true
+	}
+
+	p := []rune(strings.ToLower(pattern))
+	t := []rune(strings.ToLower(text))
+
+	score := 0
+	pi := 0
+	prevMatch := -2
+	for ti := 0; ti < len(t) && pi < len(p); ti++ {
+		if t[ti] != p[pi] {
+			continue
+		}
+		score++
+		if ti == prevMatch+1 {
+			score += 2 // Consecutive characters
+		}
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+			score += 3 // Start of a word
+		}
+		prevMatch = ti
+		pi++
+	}
+
+	if pi < len(p) {
+		return 0, false
+	}
+	// Prefer shorter texts when scores tie
+	return score*100 - len(t), true
+}
+
+// currentBoardKey returns the key of the board currently shown
+func (m Model) currentBoardKey() string {
+	if m.isBeadsBackend() {
+		return beadsBoardKey
+	}
+	return m.boardFile
+}
+
+// saveBoardState remembers the selection and filter for the current board
+func (m *Model) saveBoardState() {
+	if m.boardStates == nil {
+		m.boardStates = make(map[string]boardViewState)
+	}
+
+	offsets := make(map[int]int, len(m.columnScrollOffset))
+	for k, v := range m.columnScrollOffset {
+		offsets[k] = v
+	}
+
+	m.boardStates[m.currentBoardKey()] = boardViewState{
+		selectedColumn:     m.selectedColumn,
+		selectedTask:       m.selectedTask,
+		filterText:         m.filterText,
+		epicFocus:          m.epicFocus,
+		visibleColumnStart: m.visibleColumnStart,
+		columnScrollOffset: offsets,
+		laneMode:           m.laneMode,
+		selectedLane:       m.selectedLane,
+		collapsedLanes:     m.collapsedLanes,
+	}
+}
+
+// restoreBoardState restores the remembered state for the current board,
+// clamped to the board as it is now
+func (m *Model) restoreBoardState() {
+	state, ok := m.boardStates[m.currentBoardKey()]
+	if !ok {
+		state = boardViewState{columnScrollOffset: make(map[int]int)}
+	}
+
+	m.selectedColumn = state.selectedColumn
+	m.selectedTask = state.selectedTask
+	m.filterText = state.filterText
+	m.epicFocus = state.epicFocus
+	m.visibleColumnStart = state.visibleColumnStart
+	m.columnScrollOffset = state.columnScrollOffset
+	m.laneMode = state.laneMode
+	m.selectedLane = state.selectedLane
+	m.collapsedLanes = state.collapsedLanes
+
+	if m.selectedColumn >= len(m.board.Columns) {
+		m.selectedColumn = 0
+	}
+	if col := m.getCurrentColumn(); col == nil || m.selectedTask >= len(col.Tasks) {
+		m.selectedTask = 0
+	}
+	if m.laneMode != LaneNone {
+		m.syncLaneToTask()
+	}
+
+	m.cachedIssueDetails = nil
+	m.cachedIssueID = ""
+	m.calculateLayout()
+	m.updateScrollOffset()
+}
+
+// switchBoard saves the current board's state and opens another board
+func (m *Model) switchBoard(entry BoardEntry) error {
+	var backend Backend
+	if entry.IsBeads {
+		beads := NewBeadsBackend()
+		beads.jsonlOnly = m.beadsJSONL
+		backend = beads
+	} else {
+		backend = NewLocalBackend(entry.Path)
+	}
+
+	board, err := backend.LoadBoard()
+	if err != nil {
+		return err
+	}
+
+	m.saveBoardState()
+	m.backend = backend
+	if !entry.IsBeads {
+		m.boardFile = entry.Path
+	}
+	m.board = board
+	m.restoreBoardState()
+	m.fetchIssueDetails()
+	return nil
+}
+
+// openBoardSwitcher discovers boards and shows the switcher overlay
+func (m *Model) openBoardSwitcher() {
+	dir := m.boardsDir
+	if dir == "" {
+		dir = filepath.Dir(m.boardFile)
+	}
+	entries := discoverBoards(dir, beadsProjectDetected())
+
+	// Always offer the current board file, even if it hasn't been saved yet
+	found := false
+	for i, e := range entries {
+		if !e.IsBeads && sameFile(e.Path, m.boardFile) {
+			found = true
+			entries[i].Key = m.boardFile
+			entries[i].Path = m.boardFile
+		}
+	}
+	if !found && m.boardFile != "" {
+		entries = append([]BoardEntry{{
+			Key:  m.boardFile,
+			Name: filepath.Base(m.boardFile),
+			Path: m.boardFile,
+		}}, entries...)
+	}
+
+	// The open board's count comes from memory rather than disk
+	for i := range entries {
+		if entries[i].Key == m.currentBoardKey() {
+			entries[i].TaskCount = len(m.board.Tasks)
+			if m.board.Name != "" {
+				entries[i].Name = m.board.Name
+			}
+		}
+	}
+
+	m.switcherEntries = entries
+	m.switcherIndex = 0
+	m.switcherActive = true
+	m.switcherInput = textinput.New()
+	m.switcherInput.Placeholder = "Search boards..."
+	m.switcherInput.CharLimit = 100
+	m.switcherInput.Width = 40
+	m.switcherInput.Focus()
+}
+
+// closeBoardSwitcher hides the switcher overlay
+func (m *Model) closeBoardSwitcher() {
+	m.switcherActive = false
+	m.switcherEntries = nil
+}
+
+// filteredBoardEntries returns the switcher entries matching the search, best first
+func (m Model) filteredBoardEntries() []BoardEntry {
+	query := m.switcherInput.Value()
+
+	type scored struct {
+		entry BoardEntry
+		score int
+	}
+	var matches []scored
+	for _, e := range m.switcherEntries {
+		score, ok := fuzzyScore(query, e.Name+" "+filepath.Base(e.Path))
+		if ok {
+			matches = append(matches, scored{e, score})
+		}
+	}
+	if query != "" {
+		sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+	}
+
+	result := make([]BoardEntry, len(matches))
+	for i, s := range matches {
+		result[i] = s.entry
+	}
+	return result
+}
+
+// sameFile reports whether two paths refer to the same file
+func sameFile(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && absA == absB
+}
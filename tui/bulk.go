@@ -0,0 +1,539 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// bulk.go - Multi-select and batch operations on cards
+// Cards are marked with space (V marks a range, * everything matching the
+// filter); x opens the batch menu whose action is applied to every marked
+// card through the backend after a single confirmation
+
+// batchStep is the part of the batch overlay currently shown
+type batchStep int
+
+const (
+	batchChooseAction   batchStep = iota // List of actions
+	batchChooseColumn                    // Target column for move
+	batchChoosePriority                  // New priority
+	batchEnterText                       // Label or assignee
+	batchConfirm                         // y/n before applying
+)
+
+// batchAction is an operation applied to every marked card
+type batchAction int
+
+const (
+	batchMove batchAction = iota
+	batchPriority
+	batchAddLabel
+	batchRemoveLabel
+	batchAssign
+	batchClose
+	batchDelete
+)
+
+// batchActionLabels are shown in the batch menu, in batchAction order
+var batchActionLabels = []string{
+	"Move to column...",
+	"Set priority...",
+	"Add label...",
+	"Remove label...",
+	"Assign to...",
+	"Close (move to last column)",
+	"Delete",
+}
+
+// batchPriorities are offered by "Set priority", highest first
+var batchPriorities = []Priority{PriorityUrgent, PriorityHigh, PriorityMedium, PriorityLow}
+
+// batchState holds the batch overlay's progress
+type batchState struct {
+	step   batchStep
+	action batchAction
+	index  int             // Highlighted row in the current list
+	input  textinput.Model // Label/assignee entry
+	column string          // Chosen column ID (move)
+	value  Priority        // Chosen priority
+	text   string          // Entered label/assignee
+}
+
+// isMarked reports whether a task is part of the multi-selection
+func (m Model) isMarked(task *Task) bool {
+	return task != nil && m.marked[task.ID]
+}
+
+// markedTasks returns the marked tasks in board order
+func (m Model) markedTasks() []*Task {
+	var tasks []*Task
+	for _, col := range m.board.Columns {
+		for _, task := range col.Tasks {
+			if m.marked[task.ID] {
+				tasks = append(tasks, task)
+			}
+		}
+	}
+	return tasks
+}
+
+// toggleMark marks or unmarks the current task and makes it the range anchor
+func (m *Model) toggleMark() {
+	task := m.getCurrentTask()
+	if task == nil {
+		return
+	}
+	if m.marked == nil {
+		m.marked = make(map[string]bool)
+	}
+	if m.marked[task.ID] {
+		delete(m.marked, task.ID)
+	} else {
+		m.marked[task.ID] = true
+	}
+	m.markAnchor = task.ID
+}
+
+// markRange marks every card between the anchor and the current card,
+// in board order (columns left to right, cards top to bottom)
+func (m *Model) markRange() {
+	current := m.getCurrentTask()
+	if current == nil {
+		return
+	}
+	if m.markAnchor == "" {
+		m.toggleMark()
+		return
+	}
+
+	var ordered []*Task
+	for _, col := range m.board.Columns {
+		ordered = append(ordered, col.Tasks...)
+	}
+
+	from, to := -1, -1
+	for i, task := range ordered {
+		if task.ID == m.markAnchor {
+			from = i
+		}
+		if task == current {
+			to = i
+		}
+	}
+	if from == -1 || to == -1 {
+		m.toggleMark()
+		return
+	}
+	if from > to {
+		from, to = to, from
+	}
+
+	if m.marked == nil {
+		m.marked = make(map[string]bool)
+	}
+	for _, task := range ordered[from : to+1] {
+		m.marked[task.ID] = true
+	}
+	m.markAnchor = current.ID
+}
+
+// markAllMatching marks every card matching the current filter (all cards without one)
+func (m *Model) markAllMatching() {
+	if m.marked == nil {
+		m.marked = make(map[string]bool)
+	}
+	count := 0
+	for _, col := range m.board.Columns {
+		for _, task := range col.Tasks {
+			if m.taskMatchesFilter(task) {
+				m.marked[task.ID] = true
+				count++
+			}
+		}
+	}
+	m.statusMessage = fmt.Sprintf("Selected %d cards", count)
+}
+
+// clearMarks empties the multi-selection
+func (m *Model) clearMarks() {
+	m.marked = nil
+	m.markAnchor = ""
+}
+
+// openBatchMenu opens the batch action overlay for the marked cards
+func (m *Model) openBatchMenu() {
+	if len(m.markedTasks()) == 0 {
+		m.statusMessage = "No cards selected (space to select, V for a range, * for all matching)"
+		return
+	}
+	m.batch = batchState{step: batchChooseAction}
+	m.batchActive = true
+}
+
+// closeBatchMenu closes the batch overlay, keeping the selection
+func (m *Model) closeBatchMenu() {
+	m.batchActive = false
+	m.batch = batchState{}
+}
+
+// batchListLen returns how many rows the current batch list has
+func (m Model) batchListLen() int {
+	switch m.batch.step {
+	case batchChooseAction:
+		return len(batchActionLabels)
+	case batchChooseColumn:
+		return len(m.board.Columns)
+	case batchChoosePriority:
+		return len(batchPriorities)
+	}
+	return 0
+}
+
+// chooseBatchRow handles enter on a batch list row, advancing to the next step
+func (m *Model) chooseBatchRow() {
+	switch m.batch.step {
+	case batchChooseAction:
+		m.batch.action = batchAction(m.batch.index)
+		m.batch.index = 0
+		switch m.batch.action {
+		case batchMove:
+			m.batch.step = batchChooseColumn
+		case batchPriority:
+			m.batch.step = batchChoosePriority
+		case batchAddLabel, batchRemoveLabel, batchAssign:
+			input := textinput.New()
+			input.CharLimit = 100
+			input.Width = 30
+			input.Placeholder = "label"
+			if m.batch.action == batchAssign {
+				input.Placeholder = "assignee"
+			}
+			input.Focus()
+			m.batch.input = input
+			m.batch.step = batchEnterText
+		default:
+			m.batch.step = batchConfirm
+		}
+
+	case batchChooseColumn:
+		m.batch.column = m.board.Columns[m.batch.index].ID
+		m.batch.step = batchConfirm
+
+	case batchChoosePriority:
+		m.batch.value = batchPriorities[m.batch.index]
+		m.batch.step = batchConfirm
+
+	case batchEnterText:
+		m.batch.text = strings.TrimSpace(m.batch.input.Value())
+		if m.batch.text == "" && m.batch.action != batchAssign {
+			return // A label is required; an empty assignee unassigns
+		}
+		m.batch.step = batchConfirm
+	}
+}
+
+// describeBatch returns what the pending batch action will do
+func (m Model) describeBatch() string {
+	switch m.batch.action {
+	case batchMove:
+		if col := findColumnByID(m.board, m.batch.column); col != nil {
+			return "Move to " + col.Title
+		}
+	case batchPriority:
+		return "Set priority " + m.batch.value.String()
+	case batchAddLabel:
+		return "Add label " + m.batch.text
+	case batchRemoveLabel:
+		return "Remove label " + m.batch.text
+	case batchAssign:
+		if m.batch.text == "" {
+			return "Unassign"
+		}
+		return "Assign to " + m.batch.text
+	case batchClose:
+		return "Close"
+	case batchDelete:
+		return "Delete"
+	}
+	return ""
+}
+
+// applyBatch runs the confirmed batch action on every marked card
+func (m *Model) applyBatch() {
+	tasks := m.markedTasks()
+	action := m.describeBatch()
+	failed := 0
+	var saveErr error
+
+	switch m.batch.action {
+	case batchMove, batchClose:
+		target := m.batch.column
+		if m.batch.action == batchClose {
+			target = m.board.Columns[len(m.board.Columns)-1].ID
+		}
+		if m.promptResolution(tasks, target) {
+			m.closeBatchMenu() // The resolution prompt closes the cards
+			return
+		}
+		failed, saveErr = m.moveTasksTo(tasks, target)
+
+	case batchDelete:
+		if m.isBeadsBackend() {
+			// Beads can't delete: close with a resolution, as a single delete does
+			if !m.promptResolution(tasks, statusColumn(m.board, StatusClosed).ID) {
+				m.statusMessage = "The selected cards are already closed"
+			}
+			m.closeBatchMenu()
+			return
+		}
+		for _, task := range tasks {
+			if m.backend != nil && m.backend.DeleteTask(task.ID) != nil {
+				failed++
+				continue
+			}
+			m.removeTaskFromBoard(task)
+		}
+		if m.backend != nil {
+			saveErr = m.backend.SaveBoard(m.board)
+		}
+
+	default:
+		for _, task := range tasks {
+			switch m.batch.action {
+			case batchPriority:
+				task.Priority = m.batch.value
+			case batchAddLabel:
+				if labels := taskLabels(task); !containsString(labels, m.batch.text) {
+					setTaskLabels(task, append(labels[:len(labels):len(labels)], m.batch.text))
+				}
+			case batchRemoveLabel:
+				var labels []string
+				for _, label := range taskLabels(task) {
+					if label != m.batch.text {
+						labels = append(labels, label)
+					}
+				}
+				if len(labels) != len(taskLabels(task)) {
+					setTaskLabels(task, labels)
+				}
+			case batchAssign:
+				task.Assignee = m.batch.text
+			}
+			if m.backend != nil && m.backend.UpdateTask(task) != nil {
+				failed++
+			}
+		}
+	}
+
+	m.statusMessage = fmt.Sprintf("%s: %d cards", action, len(tasks)-failed)
+	if failed > 0 {
+		m.statusMessage += fmt.Sprintf(", %d failed", failed)
+	}
+	m.statusMessage += saveFailure(saveErr)
+
+	if m.batch.action == batchDelete {
+		m.clearMarks()
+	}
+	m.closeBatchMenu()
+	m.clampSelection()
+}
+
+// moveTasksTo moves tasks to the end of a column through the backend,
+// returning how many moves failed and whether the board could be saved
+func (m *Model) moveTasksTo(tasks []*Task, columnID string) (failed int, err error) {
+	for _, task := range tasks {
+		if task.ColumnID == columnID {
+			continue
+		}
+		if !m.isDoneColumn(columnID) {
+			task.Resolution = "" // Reopened
+		}
+		if m.backend != nil && m.backend.MoveTask(task.ID, columnID) != nil {
+			failed++
+			continue
+		}
+		m.relocateTask(task, columnID)
+	}
+	if m.backend != nil {
+		err = m.backend.SaveBoard(m.board)
+	}
+	return failed, err
+}
+
+// saveFailure is appended to a status message when the board wasn't saved
+func saveFailure(err error) string {
+	if err == nil {
+		return ""
+	}
+	return "; board not saved: " + err.Error()
+}
+
+// removeTaskFromBoard drops a task from the in-memory board
+func (m *Model) removeTaskFromBoard(task *Task) {
+	for i, t := range m.board.Tasks {
+		if t == task {
+			m.board.Tasks = append(m.board.Tasks[:i], m.board.Tasks[i+1:]...)
+			break
+		}
+	}
+	for i := range m.board.Columns {
+		col := &m.board.Columns[i]
+		for j, t := range col.Tasks {
+			if t == task {
+				col.Tasks = append(col.Tasks[:j], col.Tasks[j+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// clampSelection keeps the selected card index within the current column
+func (m *Model) clampSelection() {
+	col := m.getCurrentColumn()
+	if col == nil {
+		return
+	}
+	if m.selectedTask >= len(col.Tasks) {
+		m.selectedTask = len(col.Tasks) - 1
+	}
+	if m.selectedTask < 0 {
+		m.selectedTask = 0
+	}
+	if m.laneMode != LaneNone {
+		m.syncLaneToTask()
+	}
+}
+
+// renderBatchMenu renders the batch action overlay
+func (m Model) renderBatchMenu(background string) string {
+	var content strings.Builder
+	count := len(m.markedTasks())
+
+	content.WriteString(styleDetailTitle.Render(fmt.Sprintf("Batch - %d cards", count)))
+	content.WriteString("\n\n")
+
+	var rows []string
+	hint := "↑/↓: Select | Enter: Choose | Esc: Back"
+	switch m.batch.step {
+	case batchChooseAction:
+		rows = batchActionLabels
+	case batchChooseColumn:
+		for _, col := range m.board.Columns {
+			rows = append(rows, col.Title)
+		}
+	case batchChoosePriority:
+		for _, p := range batchPriorities {
+			rows = append(rows, p.String())
+		}
+	case batchEnterText:
+		content.WriteString(styleDetailLabel.Render(batchActionLabels[m.batch.action]))
+		content.WriteString("\n")
+		content.WriteString(m.batch.input.View())
+		content.WriteString("\n")
+		hint = "Enter: Continue | Esc: Back"
+	case batchConfirm:
+		prompt := fmt.Sprintf("%s for %d cards?", m.describeBatch(), count)
+		if m.batch.action == batchDelete {
+			content.WriteString(lipgloss.NewStyle().Foreground(colorDanger).Bold(true).Render(prompt))
+		} else {
+			content.WriteString(styleDetailValue.Render(prompt))
+		}
+		content.WriteString("\n")
+		hint = "y: Apply | n/Esc: Cancel"
+	}
+
+	for i, row := range rows {
+		if i == m.batch.index {
+			content.WriteString(styleFormSelected.Render(row))
+		} else {
+			content.WriteString(styleFormOption.Render(row))
+		}
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	content.WriteString(styleSubdued.Render(hint))
+
+	overlay := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorPrimary).
+		Padding(1, 2).
+		Width(50).
+		Render(content.String())
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay)
+}
+
+// handleBatchKeyMsg handles keyboard input when the batch overlay is open
+func (m Model) handleBatchKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch m.batch.step {
+	case batchConfirm:
+		switch msg.String() {
+		case "y", "Y":
+			m.applyBatch()
+		case "n", "N", "esc":
+			m.closeBatchMenu()
+		}
+		return m, nil
+
+	case batchEnterText:
+		switch msg.String() {
+		case "esc":
+			m.batch.step = batchChooseAction
+			m.batch.index = int(m.batch.action)
+			return m, nil
+		case "enter":
+			m.chooseBatchRow()
+			return m, nil
+		}
+		m.batch.input, cmd = m.batch.input.Update(msg)
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "esc":
+		if m.batch.step == batchChooseAction {
+			m.closeBatchMenu()
+		} else {
+			m.batch.step = batchChooseAction
+			m.batch.index = int(m.batch.action)
+		}
+
+	case "up", "k":
+		if m.batch.index > 0 {
+			m.batch.index--
+		}
+
+	case "down", "j":
+		if m.batch.index < m.batchListLen()-1 {
+			m.batch.index++
+		}
+
+	case "enter":
+		m.chooseBatchRow()
+	}
+
+	return m, nil
+}
+
+// dropMarkedTasks moves every marked card to the column they were dragged onto
+func (m *Model) dropMarkedTasks(toColIndex int) {
+	tasks := m.markedTasks()
+	col := m.board.Columns[toColIndex]
+	if m.promptResolution(tasks, col.ID) {
+		return
+	}
+	failed, err := m.moveTasksTo(tasks, col.ID)
+
+	m.statusMessage = fmt.Sprintf("Moved %d cards to %s", len(tasks)-failed, col.Title)
+	if failed > 0 {
+		m.statusMessage += fmt.Sprintf(", %d failed", failed)
+	}
+	m.statusMessage += saveFailure(err)
+}
@@ -0,0 +1,299 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// capture.go - Quick-capture syntax for new tasks
+// Titles typed into Quick Add (or given to the add command) can carry inline
+// tokens: !p1 priority, #bug type (other #words are labels), +auth label,
+// @alice assignee, ^kb-12 blocked by, due:fri due date and ~2h estimate.
+// A leading backslash keeps a word as typed (\#bug)
+
+// captureFields are the fields parsed from a quick-capture title
+type captureFields struct {
+	title       string
+	issueType   string // "" when no #type was given
+	priority    Priority
+	hasPriority bool
+	labels      []string
+	assignee    string
+	blockedBy   []string
+	due         string // YYYY-MM-DD
+	estimate    string
+	problems    []string // Tokens that didn't parse; they stay in the title
+	externalID  string   // Set by importers, see import.go
+}
+
+// captureWeekdays maps weekday names and abbreviations for due: tokens
+var captureWeekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// parseCapture splits quick-capture tokens out of a title; due dates are
+// relative to today
+func parseCapture(text string, today time.Time) captureFields {
+	var c captureFields
+	var words []string
+	for _, word := range strings.Fields(text) {
+		if literal, ok := strings.CutPrefix(word, `\`); ok && literal != "" {
+			words = append(words, literal)
+			continue
+		}
+		if !c.parseToken(word, today) {
+			words = append(words, word)
+		}
+	}
+	c.title = strings.Join(words, " ")
+	return c
+}
+
+// parseToken applies one capture token, reporting whether word was one
+func (c *captureFields) parseToken(word string, today time.Time) bool {
+	lower := strings.ToLower(word)
+	switch {
+	case len(word) == 3 && strings.HasPrefix(lower, "!p") && word[2] >= '0' && word[2] <= '4':
+		c.priority = beadsPriority(int(word[2] - '0'))
+		c.hasPriority = true
+	case strings.HasPrefix(word, "#") && startsWithLetter(word[1:]):
+		if c.issueType == "" && containsString(issueTypes, lower[1:]) {
+			c.issueType = lower[1:]
+		} else {
+			c.addLabel(word[1:])
+		}
+	case strings.HasPrefix(word, "+") && startsWithLetter(word[1:]):
+		c.addLabel(word[1:])
+	case strings.HasPrefix(word, "@") && len(word) > 1:
+		c.assignee = word[1:]
+	case strings.HasPrefix(word, "^") && len(word) > 1:
+		if !containsString(c.blockedBy, word[1:]) {
+			c.blockedBy = append(c.blockedBy, word[1:])
+		}
+	case strings.HasPrefix(lower, "due:"):
+		due, err := parseDueDate(word[4:], today)
+		if err != nil {
+			c.problems = append(c.problems, err.Error())
+			return false
+		}
+		c.due = due
+	case strings.HasPrefix(word, "~") && len(word) > 1 && unicode.IsDigit(rune(word[1])):
+		if _, err := parseEstimate(word[1:]); err != nil {
+			c.problems = append(c.problems, err.Error())
+			return false
+		}
+		c.estimate = word[1:]
+	default:
+		return false
+	}
+	return true
+}
+
+// addLabel adds a label once
+func (c *captureFields) addLabel(label string) {
+	if !containsString(c.labels, label) {
+		c.labels = append(c.labels, label)
+	}
+}
+
+// startsWithLetter reports whether s starts with a letter, so "#12" and
+// "+1" stay in titles
+func startsWithLetter(s string) bool {
+	for _, r := range s {
+		return unicode.IsLetter(r)
+	}
+	return false
+}
+
+// beadsPriority maps a P0-P4 number to a priority (P3 and P4 are both low)
+func beadsPriority(p int) Priority {
+	var beads *BeadsBackend // The mapping doesn't need a backend
+	return beads.beadsPriorityToPriority(p)
+}
+
+// parseDueDate reads a due date: YYYY-MM-DD, today, tomorrow, a weekday (the
+// next one after today) or a number of days or weeks ahead (3d, 2w)
+func parseDueDate(text string, today time.Time) (string, error) {
+	lower := strings.ToLower(text)
+	day := func(offset int) string { return today.AddDate(0, 0, offset).Format("2006-01-02") }
+	switch lower {
+	case "today":
+		return day(0), nil
+	case "tomorrow", "tom":
+		return day(1), nil
+	}
+	if len(lower) >= 3 {
+		if weekday, ok := captureWeekdays[lower[:3]]; ok && strings.HasPrefix(strings.ToLower(weekday.String()), lower) {
+			offset := (int(weekday)-int(today.Weekday())+6)%7 + 1
+			return day(offset), nil
+		}
+	}
+	if len(lower) > 1 {
+		if n, err := strconv.Atoi(lower[:len(lower)-1]); err == nil && n >= 0 {
+			switch lower[len(lower)-1] {
+			case 'd':
+				return day(n), nil
+			case 'w':
+				return day(7 * n), nil
+			}
+		}
+	}
+	if _, err := time.Parse("2006-01-02", text); err == nil {
+		return text, nil
+	}
+	return "", fmt.Errorf("due:%s is not a date (try due:fri, due:3d or due:2026-01-31)", text)
+}
+
+// hasTokens reports whether any field besides the title was captured
+func (c captureFields) hasTokens() bool {
+	return c.issueType != "" || c.hasPriority || len(c.labels) > 0 || c.assignee != "" ||
+		len(c.blockedBy) > 0 || c.due != "" || c.estimate != "" || len(c.problems) > 0
+}
+
+// summary describes the captured fields: "P1 bug +auth @alice due 2026-01-30"
+func (c captureFields) summary() []string {
+	var parts []string
+	if c.hasPriority {
+		parts = append(parts, priorityShortLabel(c.priority))
+	}
+	if c.issueType != "" {
+		parts = append(parts, c.issueType)
+	}
+	for _, label := range c.labels {
+		parts = append(parts, "+"+label)
+	}
+	if c.assignee != "" {
+		parts = append(parts, "@"+c.assignee)
+	}
+	if len(c.blockedBy) > 0 {
+		parts = append(parts, "blocked by "+strings.Join(c.blockedBy, ", "))
+	}
+	if c.due != "" {
+		parts = append(parts, "due "+c.due)
+	}
+	if c.estimate != "" {
+		parts = append(parts, "~"+c.estimate)
+	}
+	return parts
+}
+
+// priorityShortLabel returns the P0-P3 label the form uses for a priority
+func priorityShortLabel(p Priority) string {
+	var beads *BeadsBackend
+	return "P" + strconv.Itoa(beads.priorityToBeadsPriority(p))
+}
+
+// createTask creates the captured task in a column, then sets the fields
+// CreateTask doesn't take. The task is returned even when that update fails
+func (c captureFields) createTask(backend Backend, columnID, description string) (*Task, error) {
+	task, err := backend.CreateTask(c.title, description, columnID, c.issueType, c.priority)
+	if err != nil {
+		return nil, err
+	}
+	if len(c.labels) == 0 && c.assignee == "" && len(c.blockedBy) == 0 && c.due == "" && c.estimate == "" && c.externalID == "" {
+		return task, nil
+	}
+
+	for _, label := range c.labels {
+		if !containsString(task.Labels, label) {
+			task.Labels = append(task.Labels, label)
+		}
+	}
+	task.Assignee = c.assignee
+	task.BlockedBy = c.blockedBy
+	task.IsReady = len(c.blockedBy) == 0
+	task.DueDate = c.due
+	task.Estimate = c.estimate
+	task.ExternalID = c.externalID
+	return task, backend.UpdateTask(task)
+}
+
+// renderCapturePreview renders the fields parsed from the Quick Add title
+func renderCapturePreview(c captureFields) string {
+	var content strings.Builder
+	if parts := c.summary(); len(parts) > 0 {
+		content.WriteString(styleSubdued.Render("→ "))
+		for i, part := range parts {
+			if i > 0 {
+				content.WriteString(styleSubdued.Render(" · "))
+			}
+			if label, ok := strings.CutPrefix(part, "+"); ok {
+				content.WriteString(lipgloss.NewStyle().Foreground(labelColor(label)).Render(sanitizeLine(part)))
+			} else {
+				content.WriteString(styleDetailValue.Render(sanitizeLine(part)))
+			}
+		}
+	}
+	for _, problem := range c.problems {
+		if content.Len() > 0 {
+			content.WriteString("\n")
+		}
+		content.WriteString(lipgloss.NewStyle().Foreground(colorWarning).Render("! " + sanitizeLine(problem)))
+	}
+	return content.String()
+}
+
+// runAdd implements the add subcommand: create a task from quick-capture text
+func runAdd(args []string) {
+	fs := flag.NewFlagSet("add", flag.ExitOnError)
+	backendOpts := registerBackendFlags(fs)
+	column := fs.String("column", "", "Column ID or title to add the task to (default: the first column)")
+	description := fs.String("description", "", "Task description")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: ai-kanban-tui add [flags] Fix login redirect !p1 #bug +auth @alice ^kb-12 due:fri ~2h")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	c := parseCapture(strings.Join(fs.Args(), " "), time.Now())
+	for _, problem := range c.problems {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", problem)
+	}
+	if c.title == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+	if !c.hasPriority {
+		c.priority = PriorityMedium
+	}
+
+	backend := backendOpts.openBackend()
+	board, err := backend.LoadBoard()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading board: %v\n", err)
+		os.Exit(1)
+	}
+	if len(board.Columns) == 0 {
+		fmt.Fprintln(os.Stderr, "Error: board has no columns")
+		os.Exit(1)
+	}
+	col := &board.Columns[0]
+	if *column != "" {
+		if col = resolveColumn(board, *column); col == nil {
+			fmt.Fprintf(os.Stderr, "Unknown column %q\n", *column)
+			os.Exit(1)
+		}
+	}
+
+	task, err := c.createTask(backend, col.ID, *description)
+	if task == nil {
+		fmt.Fprintf(os.Stderr, "Error creating task: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Created %s in %s: %s\n", task.ID, col.Title, task.Title)
+	if parts := c.summary(); len(parts) > 0 {
+		fmt.Printf("  %s\n", strings.Join(parts, ", "))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCapture(t *testing.T) {
+	today := time.Date(2026, 1, 14, 9, 0, 0, 0, time.UTC) // A Wednesday
+	c := parseCapture(`Fix login redirect !p1 #bug +auth @alice ^ai-kanban-board-0j1 due:fri ~2h for \#12 +1`, today)
+
+	if c.title != "Fix login redirect for #12 +1" {
+		t.Errorf("title %q", c.title)
+	}
+	if !c.hasPriority || c.priority != PriorityHigh || c.issueType != "bug" {
+		t.Errorf("priority %v (set %v), type %q", c.priority, c.hasPriority, c.issueType)
+	}
+	if strings.Join(c.labels, ",") != "auth" || c.assignee != "alice" || strings.Join(c.blockedBy, ",") != "ai-kanban-board-0j1" {
+		t.Errorf("labels %v, assignee %q, blocked by %v", c.labels, c.assignee, c.blockedBy)
+	}
+	if c.due != "2026-01-16" || c.estimate != "2h" {
+		t.Errorf("due %q, estimate %q", c.due, c.estimate)
+	}
+
+	c = parseCapture("Tidy up #docs due:someday", today)
+	if c.title != "Tidy up due:someday" || c.issueType != "" || strings.Join(c.labels, ",") != "docs" || len(c.problems) != 1 {
+		t.Errorf("got title %q, type %q, labels %v, problems %v", c.title, c.issueType, c.labels, c.problems)
+	}
+}
+
+func TestParseDueDate(t *testing.T) {
+	today := time.Date(2026, 1, 14, 9, 0, 0, 0, time.UTC) // A Wednesday
+	cases := map[string]string{
+		"today":      "2026-01-14",
+		"tom":        "2026-01-15",
+		"wed":        "2026-01-21",
+		"Monday":     "2026-01-19",
+		"3d":         "2026-01-17",
+		"2w":         "2026-01-28",
+		"2026-02-01": "2026-02-01",
+	}
+	for text, want := range cases {
+		if got, err := parseDueDate(text, today); got != want || err != nil {
+			t.Errorf("due:%s = %q, %v, want %q", text, got, err, want)
+		}
+	}
+	if _, err := parseDueDate("frix", today); err == nil {
+		t.Error("due:frix was accepted")
+	}
+}
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// commands.go - Command registry
+// Every board action is a Command: keys are dispatched through the registry,
+// the palette (ctrl+k or :) lists it, and the help screen and --help are
+// generated from it. New features add their command here. Keys can be
+// remapped by command ID in the user config (see config.go)
+
+// Command is an action with default keys, shown in the palette and help
+type Command struct {
+	ID      string   // Stable name, e.g. "task.move-next"
+	Title   string   // Shown in the palette and help
+	Section string   // Help section heading
+	Keys    []string // Keys as reported by tea.KeyMsg.String()
+	Hint    string   // Help label when the keys aren't plain keys (mouse, forms)
+	Global  bool     // Active in every view, not only the board
+	Run     func(m *Model) tea.Cmd
+}
+
+// Help sections, in the order they are shown
+const (
+	sectionNavigation = "NAVIGATION"
+	sectionActions    = "ACTIONS"
+	sectionSelection  = "SELECTION"
+	sectionForm       = "QUICK-ADD FORM (when open)"
+	sectionView       = "VIEW"
+	sectionMouse      = "MOUSE"
+)
+
+var commandSections = []string{sectionNavigation, sectionActions, sectionSelection, sectionForm, sectionView, sectionMouse}
+
+// commands is the registry; entries without Run are documentation only
+// (form and mouse input are handled by their own code)
+var commands = []Command{
+	// Navigation
+	{ID: "nav.left", Title: "Move to left column", Section: sectionNavigation, Keys: []string{"h", "left"},
+		Run: navigate((*Model).moveSelectionLeft)},
+	{ID: "nav.right", Title: "Move to right column", Section: sectionNavigation, Keys: []string{"l", "right"},
+		Run: navigate((*Model).moveSelectionRight)},
+	{ID: "nav.up", Title: "Move to task above", Section: sectionNavigation, Keys: []string{"k", "up"},
+		Run: navigate((*Model).moveSelectionUp)},
+	{ID: "nav.down", Title: "Move to task below", Section: sectionNavigation, Keys: []string{"j", "down"},
+		Run: navigate((*Model).moveSelectionDown)},
+	{ID: "nav.first-column", Title: "Jump to first column", Section: sectionNavigation, Keys: []string{"g", "home"},
+		Run: navigate(func(m *Model) {
+			m.selectedColumn = 0
+			m.selectedTask = 0
+		})},
+	{ID: "nav.last-column", Title: "Jump to last column", Section: sectionNavigation, Keys: []string{"G", "end"},
+		Run: navigate(func(m *Model) {
+			m.selectedColumn = len(m.board.Columns) - 1
+			m.selectedTask = 0
+		})},
+	{ID: "palette.open", Title: "Command palette (actions and tasks)", Section: sectionNavigation, Keys: []string{"ctrl+k", ":"},
+		Run: func(m *Model) tea.Cmd { m.openPalette(); return nil }},
+
+	// Task actions
+	{ID: "task.edit", Title: "Edit selected task", Section: sectionActions, Keys: []string{"e", "enter"},
+		Run: func(m *Model) tea.Cmd { m.openEditTaskForm(); return nil }},
+	{ID: "task.new", Title: "New task (quick-add form)", Section: sectionActions, Keys: []string{"n"},
+		Run: func(m *Model) tea.Cmd { m.openCreateTaskForm(); return nil }},
+	{ID: "task.delete", Title: "Delete task (confirm with y; beads issues are closed with a resolution)", Section: sectionActions, Keys: []string{"d"},
+		Run: func(m *Model) tea.Cmd {
+			task := m.getCurrentTask()
+			switch {
+			case task == nil:
+			case m.isBeadsBackend():
+				// Beads can't delete: close with a resolution instead
+				if !m.promptResolution([]*Task{task}, statusColumn(m.board, StatusClosed).ID) {
+					m.statusMessage = task.ID + " is already closed"
+				}
+			default:
+				m.confirmingDelete = true
+				m.deletingTaskID = task.ID
+			}
+			return nil
+		}},
+	{ID: "task.move-next", Title: "Move task right", Section: sectionActions, Keys: []string{"m"},
+		Run: func(m *Model) tea.Cmd { m.moveTaskToNextColumn(); return nil }},
+	{ID: "task.move-prev", Title: "Move task left", Section: sectionActions, Keys: []string{"M"},
+		Run: func(m *Model) tea.Cmd { m.moveTaskToPrevColumn(); return nil }},
+	{ID: "task.status", Title: "Set status (picker in detail panel)", Section: sectionActions, Keys: []string{"s"},
+		Run: func(m *Model) tea.Cmd { m.openStatusPicker(); return nil }},
+	{ID: "task.defer", Title: "Defer task, optionally until a date (beads)", Section: sectionActions, Keys: []string{"D"},
+		Run: func(m *Model) tea.Cmd { m.openDeferPrompt(); return nil }},
+	{ID: "task.comment", Title: "Add a comment to the selected task", Section: sectionActions, Keys: []string{"r"},
+		Run: func(m *Model) tea.Cmd { m.openCommentPrompt(); return nil }},
+	{ID: "agent.chat", Title: "Chat about task, resuming its session", Section: sectionActions, Keys: []string{"c"},
+		Run: func(m *Model) tea.Cmd { return m.startChat(false) }},
+	{ID: "agent.chat-new", Title: "Start a new chat session", Section: sectionActions, Keys: []string{"C"},
+		Run: func(m *Model) tea.Cmd { return m.startChat(true) }},
+	{ID: "agent.run", Title: "Run column's agent on task", Section: sectionActions, Keys: []string{"a"},
+		Run: func(m *Model) tea.Cmd { m.launchAgent(); return nil }},
+	{ID: "column.edit-prompt", Title: "Edit column step prompt", Section: sectionActions, Keys: []string{"P"},
+		Run: func(m *Model) tea.Cmd { m.openPromptEditor(); return nil }},
+
+	// Multi-selection
+	{ID: "select.toggle", Title: "Mark/unmark task", Section: sectionSelection, Keys: []string{" "},
+		Run: func(m *Model) tea.Cmd {
+			// Move down afterwards so repeated presses mark a run
+			m.toggleMark()
+			m.moveSelectionDown()
+			m.updateScrollOffset()
+			return nil
+		}},
+	{ID: "select.range", Title: "Mark range from last marked task", Section: sectionSelection, Keys: []string{"V"},
+		Run: func(m *Model) tea.Cmd { m.markRange(); return nil }},
+	{ID: "select.matching", Title: "Mark all tasks matching filter", Section: sectionSelection, Keys: []string{"*"},
+		Run: func(m *Model) tea.Cmd { m.markAllMatching(); return nil }},
+	{ID: "select.batch", Title: "Batch: move, priority, label, assign, close, delete", Section: sectionSelection, Keys: []string{"x"},
+		Run: func(m *Model) tea.Cmd { m.openBatchMenu(); return nil }},
+	{ID: "select.clear", Title: "Clear selection, then epic, then filter", Section: sectionSelection, Keys: []string{"esc"},
+		Run: func(m *Model) tea.Cmd {
+			if len(m.marked) > 0 {
+				m.clearMarks()
+			} else if m.epicFocus != "" {
+				m.leaveEpic()
+			} else {
+				m.filterText = ""
+			}
+			return nil
+		}},
+
+	// Quick-add form (handled by handleFormKeyMsg)
+	{Title: "Cycle type: task/bug/feature/epic/chore", Section: sectionForm, Hint: "{ / }"},
+	{Title: "Cycle priority: P0-P3", Section: sectionForm, Hint: "[ / ]"},
+	{Title: "Next field", Section: sectionForm, Hint: "Tab"},
+	{Title: "Save", Section: sectionForm, Hint: "Enter (last field)"},
+	{Title: "Cancel", Section: sectionForm, Hint: "Esc"},
+
+	// View
+	{ID: "view.details", Title: "Toggle detail panel", Section: sectionView, Keys: []string{"tab"}, Global: true,
+		Run: func(m *Model) tea.Cmd { m.toggleDetails(); return nil }},
+	{ID: "view.scroll-details-down", Title: "Scroll the detail panel down", Section: sectionView, Keys: []string{"J", "pgdown"},
+		Run: func(m *Model) tea.Cmd { m.scrollDetails(m.detailVisibleLines() / 2); return nil }},
+	{ID: "view.scroll-details-up", Title: "Scroll the detail panel up", Section: sectionView, Keys: []string{"K", "pgup"},
+		Run: func(m *Model) tea.Cmd { m.scrollDetails(-m.detailVisibleLines() / 2); return nil }},
+	{ID: "view.filter", Title: "Filter tasks", Section: sectionView, Keys: []string{"/"},
+		Run: func(m *Model) tea.Cmd { m.openFilter(); return nil }},
+	{ID: "view.epic", Title: "Drill into epic / back out (new tasks join it)", Section: sectionView, Keys: []string{"E"},
+		Run: navigate((*Model).toggleEpicFocus)},
+	{ID: "view.show-all", Title: "Toggle show all (incl. closed)", Section: sectionView, Keys: []string{"A"},
+		Run: func(m *Model) tea.Cmd { m.toggleShowAll(); return nil }},
+	{ID: "board.switch", Title: "Switch board (fuzzy search)", Section: sectionView, Keys: []string{"b"},
+		Run: func(m *Model) tea.Cmd { m.openBoardSwitcher(); return nil }},
+	{ID: "board.toggle-backend", Title: "Toggle beads/YAML backend", Section: sectionView, Keys: []string{"B"},
+		Run: func(m *Model) tea.Cmd { m.toggleBackend(); return nil }},
+	{ID: "view.lanes", Title: "Swimlanes: off/assignee/priority/label/epic", Section: sectionView, Keys: []string{"L"},
+		Run: func(m *Model) tea.Cmd { m.cycleLaneMode(); return nil }},
+	{ID: "view.collapse-lane", Title: "Collapse/expand swimlane", Section: sectionView, Keys: []string{"z"},
+		Run: func(m *Model) tea.Cmd {
+			if m.laneMode != LaneNone {
+				m.toggleLaneCollapsed()
+			}
+			return nil
+		}},
+	{ID: "board.export", Title: "Export board: Markdown, CSV, HTML, iCalendar (filtered)", Section: sectionView, Keys: []string{"X"},
+		Run: func(m *Model) tea.Cmd { m.openExportPrompt(); return nil }},
+	{ID: "view.fleet", Title: "Agent fleet (pause/resume/kill)", Section: sectionView, Keys: []string{"F"},
+		Run: func(m *Model) tea.Cmd { return m.openFleetView() }},
+	{ID: "view.help", Title: "Toggle this help", Section: sectionView, Keys: []string{"?"}, Global: true,
+		Run: func(m *Model) tea.Cmd { m.toggleHelp(); return nil }},
+	{ID: "app.quit", Title: "Quit", Section: sectionView, Keys: []string{"q", "ctrl+c"}, Global: true,
+		Run: func(m *Model) tea.Cmd { return tea.Quit }},
+
+	// Mouse (handled by handleBoardMouseMsg)
+	{Title: "Select task", Section: sectionMouse, Hint: "Click card"},
+	{Title: "Move between columns (marked cards move together)", Section: sectionMouse, Hint: "Drag card"},
+	{Title: "Jump to column", Section: sectionMouse, Hint: "Click header"},
+	{Title: "Drop into a lane to set its assignee/priority/label/epic", Section: sectionMouse, Hint: "Drag to lane"},
+	{Title: "Collapse/expand swimlane", Section: sectionMouse, Hint: "Click lane"},
+}
+
+// navigate wraps a selection change so the view scrolls and details refresh
+func navigate(move func(m *Model)) func(m *Model) tea.Cmd {
+	return func(m *Model) tea.Cmd {
+		move(m)
+		m.updateScrollOffset()
+		m.fetchIssueDetails() // Refresh detail panel
+		return nil
+	}
+}
+
+// commandForKey returns the command bound to a key, or nil
+// With globalOnly set, only commands active in every view are considered
+func commandForKey(key string, globalOnly bool) *Command {
+	for i := range commands {
+		c := &commands[i]
+		if c.Run == nil || (globalOnly && !c.Global) {
+			continue
+		}
+		if containsString(c.Keys, key) {
+			return c
+		}
+	}
+	return nil
+}
+
+// commandByID returns the registered command with the given ID, or nil
+func commandByID(id string) *Command {
+	for i := range commands {
+		if commands[i].ID == id && commands[i].Run != nil {
+			return &commands[i]
+		}
+	}
+	return nil
+}
+
+// runCommand runs a command against the model
+func (m Model) runCommand(c *Command) (tea.Model, tea.Cmd) {
+	cmd := c.Run(&m)
+	return m, cmd
+}
+
+// toggleHelp shows the help screen or returns to the previous view
+func (m *Model) toggleHelp() {
+	if m.viewMode == ViewHelp {
+		m.viewMode = m.previousView
+	} else {
+		m.previousView = m.viewMode
+		m.viewMode = ViewHelp
+	}
+}
+
+// keyLabels are display names for keys that aren't printable as-is
+var keyLabels = map[string]string{
+	"left":  "←",
+	"right": "→",
+	"up":    "↑",
+	"down":  "↓",
+	" ":     "Space",
+	"enter": "Enter",
+	"esc":   "Esc",
+	"tab":   "Tab",
+	"home":  "Home",
+	"end":   "End",
+}
+
+// keyLabel returns how a key is shown to the user
+func keyLabel(key string) string {
+	if label, ok := keyLabels[key]; ok {
+		return label
+	}
+	if rest, ok := strings.CutPrefix(key, "ctrl+"); ok {
+		return "Ctrl+" + strings.ToUpper(rest)
+	}
+	return key
+}
+
+// keyHint returns the key column for a command in help and the palette
+func (c Command) keyHint() string {
+	if c.Hint != "" {
+		return c.Hint
+	}
+	if len(c.Keys) == 0 {
+		return "(unbound)"
+	}
+	labels := make([]string, len(c.Keys))
+	for i, key := range c.Keys {
+		labels[i] = keyLabel(key)
+	}
+	return strings.Join(labels, " / ")
+}
+
+// commandHelp renders the registry as help text, grouped by section
+func commandHelp(keyWidth int) string {
+	var b strings.Builder
+	for i, section := range commandSections {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(section)
+		b.WriteString("\n")
+		for _, c := range commands {
+			if c.Section == section {
+				fmt.Fprintf(&b, "  %-*s %s\n", keyWidth, c.keyHint(), c.Title)
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// comments.go - Comment threads on tasks
+// Beads issues keep their comments in beads (bd comment); other boards keep
+// them in the task's comments list. r adds a comment, the detail panel shows
+// the thread (J/K or the mouse wheel scroll it) and agents run from the TUI
+// post the end of their output as a comment when they complete
+
+// agentSummaryLines is how much of a finished run's output is posted
+const agentSummaryLines = 30
+
+// postComment adds a comment to a task through its backend
+func postComment(backend Backend, task *Task, comment Comment) error {
+	if comment.CreatedAt.IsZero() {
+		comment.CreatedAt = time.Now()
+	}
+	if beads, ok := backend.(*BeadsBackend); ok {
+		return beads.AddComment(task.ID, comment)
+	}
+	task.Comments = append(task.Comments, comment)
+	if backend == nil {
+		return nil
+	}
+	return backend.UpdateTask(task)
+}
+
+// taskComments returns a task's thread: from beads for beads issues
+func taskComments(task *Task, details *BeadsIssueDetails) []Comment {
+	if details == nil || details.ID != task.ID {
+		return task.Comments
+	}
+	comments := make([]Comment, len(details.Comments))
+	for i, c := range details.Comments {
+		comments[i] = Comment{Author: c.Author, Text: c.Text, CreatedAt: c.CreatedAt}
+	}
+	return comments
+}
+
+// openCommentPrompt starts a comment on the selected task
+func (m *Model) openCommentPrompt() {
+	task := m.getCurrentTask()
+	if task == nil {
+		return
+	}
+	m.commentInput = textinput.New()
+	m.commentInput.Placeholder = "Comment"
+	m.commentInput.CharLimit = 2000
+	m.commentInput.Width = 50
+	m.commentInput.Focus()
+	m.commentTaskID = task.ID
+	m.commentActive = true
+}
+
+// saveComment posts the prompt's comment and scrolls the thread to it
+func (m *Model) saveComment() {
+	m.commentActive = false
+	text := strings.TrimSpace(m.commentInput.Value())
+	task := findTaskByID(m.board, m.commentTaskID)
+	if text == "" || task == nil {
+		return
+	}
+
+	comment := Comment{Text: text, CreatedAt: time.Now()}
+	if !m.isBeadsBackend() {
+		comment.Author = os.Getenv("USER") // bd uses its own actor
+	}
+	if err := postComment(m.backend, task, comment); err != nil {
+		m.statusMessage = err.Error()
+		return
+	}
+
+	// Show it without waiting for beads to be read again
+	if details := m.cachedIssueDetails; details != nil && details.ID == task.ID {
+		details.Comments = append(details.Comments, BeadsComment{Author: "you", Text: text, CreatedAt: comment.CreatedAt})
+	}
+	if !m.showDetails {
+		m.toggleDetails()
+	}
+	m.scrollDetails(1 << 20)
+	m.statusMessage = "Comment added to " + task.ID
+}
+
+// postAgentSummary posts the end of a completed run's output as a comment
+// by the agent
+func (m *Model) postAgentSummary(task *Task) {
+	summary := agentSummary(task.Agent.Logs)
+	if summary == "" {
+		return
+	}
+	comment := Comment{Author: string(task.Agent.Type), Text: summary}
+	if err := postComment(m.backend, task, comment); err != nil {
+		m.statusMessage = err.Error()
+	}
+}
+
+// agentSummary returns the last lines of an agent's output, trimmed
+func agentSummary(logs []string) string {
+	if len(logs) > agentSummaryLines {
+		logs = logs[len(logs)-agentSummaryLines:]
+	}
+	return strings.TrimSpace(strings.Join(logs, "\n"))
+}
+
+// handleCommentKeyMsg handles keyboard input while the comment prompt is open
+func (m Model) handleCommentKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.commentActive = false
+		return m, nil
+	case "enter":
+		m.saveComment()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.commentInput, cmd = m.commentInput.Update(msg)
+	return m, cmd
+}
+
+// renderCommentPrompt renders the comment prompt overlay
+func (m Model) renderCommentPrompt(background string) string {
+	var content strings.Builder
+	title := "Comment on " + m.commentTaskID
+	if task := findTaskByID(m.board, m.commentTaskID); task != nil {
+		title += ": " + truncateText(sanitizeLine(task.Title), 36)
+	}
+	content.WriteString(styleDetailTitle.Render(title))
+	content.WriteString("\n\n")
+	content.WriteString(m.commentInput.View())
+	content.WriteString("\n\n")
+	content.WriteString(styleSubdued.Render("Enter: Post | Esc: Cancel"))
+
+	overlay := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorPrimary).
+		Padding(1, 2).
+		Width(62).
+		Render(content.String())
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay)
+}
+
+// renderComments renders the comment thread of the detail panel
+func (m Model) renderComments(task *Task, details *BeadsIssueDetails, contentWidth int) string {
+	var content strings.Builder
+	comments := taskComments(task, details)
+	if len(comments) == 0 {
+		content.WriteString(styleSubdued.Render("No comments (r adds one)"))
+		return content.String()
+	}
+
+	content.WriteString(styleDetailLabel.Render(fmt.Sprintf("Comments (%d):", len(comments))))
+	for _, comment := range comments {
+		author := comment.Author
+		if author == "" {
+			author = "unknown"
+		}
+		content.WriteString("\n\n")
+		content.WriteString(styleDetailValue.Render(sanitizeLine(author)))
+		content.WriteString(styleSubdued.Render(" · " + formatRelativeTime(comment.CreatedAt)))
+		content.WriteString("\n")
+		content.WriteString(wrapText(comment.Text, contentWidth))
+	}
+	return content.String()
+}
+
+// detailVisibleLines is how many lines of the detail panel fit on screen
+func (m Model) detailVisibleLines() int {
+	return m.getContentHeight() - 2 // Padding (the border is outside the height)
+}
+
+// scrollDetails scrolls the selected task's detail panel by delta lines
+func (m *Model) scrollDetails(delta int) {
+	task := m.getCurrentTask()
+	if task == nil {
+		return
+	}
+	if m.detailScrollTaskID != task.ID {
+		m.detailScrollTaskID = task.ID
+		m.detailScrollOffset = 0
+	}
+
+	lines := strings.Count(m.renderDetailContent(), "\n") + 1
+	maxOffset := lines - m.detailVisibleLines()
+	m.detailScrollOffset += delta
+	if m.detailScrollOffset > maxOffset {
+		m.detailScrollOffset = maxOffset
+	}
+	if m.detailScrollOffset < 0 {
+		m.detailScrollOffset = 0
+	}
+}
+
+// scrollDetailContent cuts the detail panel content to the scrolled window
+func (m Model) scrollDetailContent(content string) string {
+	offset := 0
+	if task := m.getCurrentTask(); task != nil && task.ID == m.detailScrollTaskID {
+		offset = m.detailScrollOffset
+	}
+	lines := strings.Split(content, "\n")
+	if offset > len(lines)-1 {
+		offset = len(lines) - 1
+	}
+	lines = lines[offset:]
+	if visible := m.detailVisibleLines(); visible > 0 && len(lines) > visible {
+		lines = lines[:visible]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,276 @@
+package main
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v3"
+)
+
+// config.go - User config: theme and key bindings
+// Read from <config dir>/ai-kanban-board/config.yaml. Built-in themes are
+// embedded like the board templates; users can add their own in
+// <config dir>/ai-kanban-board/themes. Example config:
+//
+//	theme: light
+//	colors:
+//	  selected: "#ff5fd7"
+//	keys:
+//	  task.move-next: [m, ctrl+l]
+//	  select.batch: X
+
+//go:embed themes/*.yaml
+var builtinThemeFS embed.FS
+
+// defaultTheme is used when neither the config nor --theme picks one
+const defaultTheme = "dark"
+
+// Theme is a named set of colors
+type Theme struct {
+	Key          string            `yaml:"-"` // File name without extension, used by theme: and --theme
+	Name         string            `yaml:"name"`
+	Order        int               `yaml:"order"`
+	Colors       map[string]string `yaml:"colors"`        // See themeColors for names
+	ColumnColors map[string]string `yaml:"column_colors"` // Tailwind class -> terminal color
+}
+
+// UserConfig is the user's config.yaml
+type UserConfig struct {
+	Theme        string             `yaml:"theme"`
+	Colors       map[string]string  `yaml:"colors"`        // Overrides on top of the theme
+	ColumnColors map[string]string  `yaml:"column_colors"` // Overrides on top of the theme
+	Keys         map[string]keyList `yaml:"keys"`          // Command ID -> keys
+}
+
+// keyList is one key or a list of keys in the config
+type keyList []string
+
+func (k *keyList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*k = keyList{node.Value}
+		return nil
+	}
+	var keys []string
+	if err := node.Decode(&keys); err != nil {
+		return err
+	}
+	*k = keys
+	return nil
+}
+
+// themeColors maps the color names used in themes to the palette in styles.go
+var themeColors = map[string]*lipgloss.Color{
+	"background":      &colorBackground,
+	"foreground":      &colorForeground,
+	"border":          &colorBorder,
+	"divider":         &colorDivider,
+	"primary":         &colorPrimary,
+	"secondary":       &colorSecondary,
+	"success":         &colorSuccess,
+	"warning":         &colorWarning,
+	"danger":          &colorDanger,
+	"info":            &colorInfo,
+	"title":           &colorTitle,
+	"selected":        &colorSelected,
+	"subdued":         &colorSubdued,
+	"highlight":       &colorHighlight,
+	"on_accent":       &colorOnAccent,
+	"label":           &colorLabel,
+	"priority_low":    &colorPriorityLow,
+	"priority_medium": &colorPriorityMedium,
+	"priority_high":   &colorPriorityHigh,
+	"priority_urgent": &colorPriorityUrgent,
+	"agent_idle":      &colorAgentIdle,
+	"agent_running":   &colorAgentRunning,
+	"agent_paused":    &colorAgentPaused,
+	"agent_completed": &colorAgentCompleted,
+	"agent_failed":    &colorAgentFailed,
+}
+
+// userConfigPath returns where config.yaml is read from
+func userConfigPath() (string, error) {
+	dir, err := userConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.yaml"), nil
+}
+
+// loadUserConfig reads config.yaml; a missing file is an empty config
+func loadUserConfig() (UserConfig, error) {
+	var cfg UserConfig
+
+	path, err := userConfigPath()
+	if err != nil {
+		return cfg, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// loadThemes returns the built-in themes plus the user's, sorted for display
+// A user theme with the same key as a built-in replaces it
+func loadThemes() []Theme {
+	byKey := make(map[string]Theme)
+
+	files, _ := builtinThemeFS.ReadDir("themes")
+	for _, f := range files {
+		data, err := builtinThemeFS.ReadFile("themes/" + f.Name())
+		if err != nil {
+			continue
+		}
+		key := strings.TrimSuffix(f.Name(), filepath.Ext(f.Name()))
+		var theme Theme
+		if yaml.Unmarshal(data, &theme) == nil {
+			theme.Key = key
+			byKey[key] = theme
+		}
+	}
+
+	if dir, err := userConfigDir(); err == nil {
+		userFiles, _ := os.ReadDir(filepath.Join(dir, "themes"))
+		for _, f := range userFiles {
+			ext := filepath.Ext(f.Name())
+			if f.IsDir() || (ext != ".yaml" && ext != ".yml") {
+				continue
+			}
+			data, err := os.ReadFile(filepath.Join(dir, "themes", f.Name()))
+			if err != nil {
+				continue
+			}
+			var theme Theme
+			if yaml.Unmarshal(data, &theme) != nil {
+				continue
+			}
+			theme.Key = strings.TrimSuffix(f.Name(), ext)
+			if _, isBuiltin := byKey[theme.Key]; !isBuiltin && theme.Order == 0 {
+				theme.Order = 1000 // User themes sort after the built-ins
+			}
+			byKey[theme.Key] = theme
+		}
+	}
+
+	themes := make([]Theme, 0, len(byKey))
+	for _, theme := range byKey {
+		themes = append(themes, theme)
+	}
+	sort.Slice(themes, func(i, j int) bool {
+		if themes[i].Order != themes[j].Order {
+			return themes[i].Order < themes[j].Order
+		}
+		return themes[i].Key < themes[j].Key
+	})
+	return themes
+}
+
+// themeKeys lists the available theme keys for messages and --help
+func themeKeys(themes []Theme) []string {
+	keys := make([]string, len(themes))
+	for i, theme := range themes {
+		keys[i] = theme.Key
+	}
+	return keys
+}
+
+// applyUserConfig applies the theme (themeOverride, if set, wins over the
+// config's), the config's color overrides and its key bindings
+func applyUserConfig(cfg UserConfig, themeOverride string) error {
+	name := cfg.Theme
+	if themeOverride != "" {
+		name = themeOverride
+	}
+	if name == "" {
+		name = defaultTheme
+	}
+
+	themes := loadThemes()
+	var theme *Theme
+	for i := range themes {
+		if themes[i].Key == name || strings.EqualFold(themes[i].Name, name) {
+			theme = &themes[i]
+		}
+	}
+	if theme == nil {
+		return fmt.Errorf("unknown theme %q (want one of %s)", name, strings.Join(themeKeys(themes), ", "))
+	}
+
+	if err := applyColors(theme.Colors, theme.ColumnColors); err != nil {
+		return fmt.Errorf("theme %s: %w", theme.Key, err)
+	}
+	if err := applyColors(cfg.Colors, cfg.ColumnColors); err != nil {
+		return err
+	}
+	buildStyles()
+
+	return applyKeyBindings(cfg.Keys)
+}
+
+// applyColors sets palette colors by name and column colors by Tailwind class
+func applyColors(colors, columnColors map[string]string) error {
+	for name, value := range colors {
+		color, ok := themeColors[name]
+		if !ok {
+			return fmt.Errorf("unknown color %q", name)
+		}
+		*color = lipgloss.Color(value)
+	}
+	for class, value := range columnColors {
+		tailwindToTerminal[class] = lipgloss.Color(value)
+	}
+	return nil
+}
+
+// applyKeyBindings replaces the keys of the given commands. A key bound here
+// is taken away from any other command, and an empty list unbinds a command
+func applyKeyBindings(bindings map[string]keyList) error {
+	// Apply in a fixed order so the result doesn't depend on map iteration
+	ids := make([]string, 0, len(bindings))
+	for id := range bindings {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		target := commandByID(id)
+		if target == nil {
+			return fmt.Errorf("unknown command %q in keys", id)
+		}
+		var keys []string
+		for _, key := range bindings[id] {
+			if key == "space" {
+				key = " " // What tea.KeyMsg.String() reports
+			}
+			keys = append(keys, key)
+		}
+		for i := range commands {
+			c := &commands[i]
+			if c == target {
+				continue
+			}
+			var kept []string
+			for _, key := range c.Keys {
+				if !containsString(keys, key) {
+					kept = append(kept, key)
+				}
+			}
+			c.Keys = kept
+		}
+		target.Keys = keys
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// epics.go - Epics and parent/child tasks
+// A task whose issue type (first label) is "epic" groups the tasks whose
+// Parent is its ID. Epic cards show a child progress bar, E drills into an
+// epic (other cards are ghosted like a filter) and tasks created while
+// drilled in are attached to it
+
+// issueTypeEpic is the issue type (first label) of an epic
+const issueTypeEpic = "epic"
+
+// childProgress counts an epic's children and how many are done
+type childProgress struct {
+	done  int
+	total int
+}
+
+// isEpic reports whether a task is an epic
+func isEpic(task *Task) bool {
+	return len(task.Labels) > 0 && task.Labels[0] == issueTypeEpic
+}
+
+// childrenOf returns the board's tasks whose parent is the given task
+func (m Model) childrenOf(parentID string) []*Task {
+	var children []*Task
+	for _, task := range m.board.Tasks {
+		if task.Parent == parentID {
+			children = append(children, task)
+		}
+	}
+	return children
+}
+
+// isDoneColumn reports whether a column ID is the board's last (done) column
+func (m Model) isDoneColumn(columnID string) bool {
+	return len(m.board.Columns) > 0 && m.board.Columns[len(m.board.Columns)-1].ID == columnID
+}
+
+// epicProgress counts an epic's children; zero for tasks that aren't epics.
+// Children the backend didn't load (closed beads issues) count as done
+func (m Model) epicProgress(task *Task) childProgress {
+	if task == nil || !isEpic(task) {
+		return childProgress{}
+	}
+	progress := childProgress{done: task.HiddenChildren, total: task.HiddenChildren}
+	for _, child := range m.childrenOf(task.ID) {
+		progress.total++
+		if m.isDoneColumn(child.ColumnID) {
+			progress.done++
+		}
+	}
+	return progress
+}
+
+// focusedEpic returns the epic being drilled into, or nil
+func (m Model) focusedEpic() *Task {
+	if m.epicFocus == "" {
+		return nil
+	}
+	return findTaskByID(m.board, m.epicFocus)
+}
+
+// toggleEpicFocus drills into the selected epic (or the selected task's
+// epic), or back out when already drilled in
+func (m *Model) toggleEpicFocus() {
+	if m.epicFocus != "" {
+		m.leaveEpic()
+		return
+	}
+
+	task := m.getCurrentTask()
+	switch {
+	case task == nil:
+		return
+	case isEpic(task):
+		m.epicFocus = task.ID
+	case task.Parent != "":
+		m.epicFocus = task.Parent
+	default:
+		m.statusMessage = "Not an epic or part of one"
+		return
+	}
+
+	epic := m.focusedEpic()
+	if epic == nil {
+		m.statusMessage = "Epic " + m.epicFocus + " is not on the board"
+		m.epicFocus = ""
+		return
+	}
+	m.selectFirstChild()
+	progress := m.epicProgress(epic)
+	m.statusMessage = fmt.Sprintf("Epic %s: %d/%d done (E or Esc to leave)", sanitizeLine(epic.Title), progress.done, progress.total)
+}
+
+// leaveEpic stops drilling into an epic and keeps the epic selected
+func (m *Model) leaveEpic() {
+	if epic := m.focusedEpic(); epic != nil {
+		m.selectTask(epic)
+	}
+	m.epicFocus = ""
+}
+
+// selectFirstChild moves the selection to the focused epic's first child,
+// unless a child is already selected
+func (m *Model) selectFirstChild() {
+	if task := m.getCurrentTask(); task != nil && task.Parent == m.epicFocus {
+		return
+	}
+	for _, col := range m.board.Columns {
+		for _, task := range col.Tasks {
+			if task.Parent == m.epicFocus {
+				m.selectTask(task)
+				return
+			}
+		}
+	}
+}
+
+// attachToFocusedEpic makes a newly created task a child of the focused epic
+func (m *Model) attachToFocusedEpic(task *Task) {
+	if m.epicFocus == "" || task.ID == m.epicFocus {
+		return
+	}
+	task.Parent = m.epicFocus
+	if m.backend != nil {
+		if err := m.backend.UpdateTask(task); err != nil {
+			m.statusMessage = err.Error()
+		}
+	}
+}
+
+// renderEpicTag renders the tag shown on epic cards
+func renderEpicTag() string {
+	return styleEpicTag.Render("EPIC")
+}
+
+// renderProgressBar renders "███░░░ 3/7" in the given width
+// Ghost cards get plain text so the faded card style applies
+func renderProgressBar(p childProgress, width int, ghost bool) string {
+	count := fmt.Sprintf("%d/%d", p.done, p.total)
+	barWidth := width - len(count) - 1
+	if barWidth < 1 {
+		return count
+	}
+	filled := 0
+	if p.total > 0 {
+		filled = barWidth * p.done / p.total
+	}
+	full := strings.Repeat("█", filled)
+	empty := strings.Repeat("░", barWidth-filled)
+	if ghost {
+		return full + empty + " " + count
+	}
+	return lipgloss.NewStyle().Foreground(colorSuccess).Render(full) +
+		styleSubdued.Render(empty) + " " + count
+}
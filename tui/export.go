@@ -0,0 +1,532 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// export.go - Board export to Markdown, CSV, HTML and iCalendar
+// X in the TUI (or the export command) writes the board as a Markdown
+// checklist grouped by column, a CSV of every task field, a self-contained
+// HTML snapshot or an iCalendar file with an all-day event per due date.
+// Only cards matching the current filter (--filter) are exported
+
+// exportFormat is a format the board can be exported to
+type exportFormat struct {
+	key   string // --format value and file extension
+	label string
+	write func(w io.Writer, export *boardExport) error
+}
+
+// exportFormats are the export formats, in prompt order
+var exportFormats = []exportFormat{
+	{key: "md", label: "Markdown", write: writeMarkdownExport},
+	{key: "csv", label: "CSV", write: writeCSVExport},
+	{key: "html", label: "HTML", write: writeHTMLExport},
+	{key: "ics", label: "iCalendar", write: writeICSExport},
+}
+
+// findExportFormat returns the export format with a key
+func findExportFormat(key string) (exportFormat, bool) {
+	for _, format := range exportFormats {
+		if format.key == strings.ToLower(key) {
+			return format, true
+		}
+	}
+	return exportFormat{}, false
+}
+
+// boardExport is the part of a board being exported
+type boardExport struct {
+	board   *Board
+	columns []exportColumn
+	filter  string
+	at      time.Time
+}
+
+// exportColumn is a column and its exported cards
+type exportColumn struct {
+	*Column
+	Tasks []*Task
+	Done  bool // The last column: its cards are checked off
+}
+
+// newBoardExport collects the cards of board that match
+func newBoardExport(board *Board, match func(*Task) bool, filter string) *boardExport {
+	export := &boardExport{board: board, filter: filter, at: time.Now()}
+	for i := range board.Columns {
+		col := exportColumn{Column: &board.Columns[i], Done: i == len(board.Columns)-1}
+		for _, task := range board.Columns[i].Tasks {
+			if match(task) {
+				col.Tasks = append(col.Tasks, task)
+			}
+		}
+		export.columns = append(export.columns, col)
+	}
+	return export
+}
+
+// taskCount returns the number of exported cards
+func (e *boardExport) taskCount() int {
+	count := 0
+	for _, col := range e.columns {
+		count += len(col.Tasks)
+	}
+	return count
+}
+
+// writeMarkdownExport writes a checklist grouped by column, for PRs and standups
+func writeMarkdownExport(w io.Writer, export *boardExport) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", export.board.Name)
+	fmt.Fprintf(&b, "_Exported %s", export.at.Format("2006-01-02 15:04"))
+	if export.filter != "" {
+		fmt.Fprintf(&b, " · filter: `%s`", export.filter)
+	}
+	b.WriteString("_\n")
+
+	for _, col := range export.columns {
+		if len(col.Tasks) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n## %s (%d)\n\n", col.Title, len(col.Tasks))
+		for _, task := range col.Tasks {
+			check := " "
+			if col.Done {
+				check = "x"
+			}
+			fmt.Fprintf(&b, "- [%s] **%s** `%s` %s", check, markdownEscape(task.Title), task.ID, priorityShortLabel(task.Priority))
+			if kind := taskIssueType(task); kind != "" {
+				b.WriteString(" " + kind)
+			}
+			for _, label := range taskLabels(task) {
+				fmt.Fprintf(&b, " `#%s`", label)
+			}
+			if task.Assignee != "" {
+				b.WriteString(" @" + task.Assignee)
+			}
+			if task.DueDate != "" {
+				b.WriteString(" due " + task.DueDate)
+			}
+			if len(task.BlockedBy) > 0 {
+				b.WriteString(" (blocked by " + strings.Join(task.BlockedBy, ", ") + ")")
+			}
+			if task.Resolution != "" {
+				b.WriteString(" (" + markdownEscape(task.Resolution) + ")")
+			}
+			b.WriteString("\n")
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownEscape keeps text from being read as Markdown emphasis or links
+func markdownEscape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`).Replace(sanitizeLine(text))
+}
+
+// csvExportHeader names the CSV columns; agent logs are left out
+var csvExportHeader = []string{
+	"id", "title", "description", "column_id", "column", "order", "priority", "type", "labels",
+	"assignee", "estimate", "due_date", "created_at", "updated_at",
+	"agent_type", "agent_status", "agent_session_id", "agent_started_at",
+	"blocked_by", "blocking", "is_ready", "critical_path",
+	"git_worktree", "git_branch", "git_base_branch", "pr_number", "pr_status", "pr_url",
+	"parent", "external_id", "resolution", "history", "sessions", "comments",
+}
+
+// writeCSVExport writes one row per task with every task field
+func writeCSVExport(w io.Writer, export *boardExport) error {
+	out := csv.NewWriter(w)
+	out.Write(csvExportHeader)
+	for _, col := range export.columns {
+		for _, task := range col.Tasks {
+			out.Write(csvExportRow(task, col.Title))
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// csvExportRow returns a task's CSV row, in csvExportHeader order
+func csvExportRow(task *Task, column string) []string {
+	timestamp := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+	var agentType, agentStatus, agentSession, agentStarted string
+	if task.Agent != nil {
+		agentType, agentStatus, agentSession = string(task.Agent.Type), string(task.Agent.Status), task.Agent.SessionID
+		if task.Agent.StartedAt != nil {
+			agentStarted = timestamp(*task.Agent.StartedAt)
+		}
+	}
+	var git GitInfo
+	if task.Git != nil {
+		git = *task.Git
+	}
+	prNumber := ""
+	if git.PRNumber != 0 {
+		prNumber = strconv.Itoa(git.PRNumber)
+	}
+
+	var history, sessions, comments []string
+	for _, entry := range task.History {
+		line := timestamp(entry.At) + " " + entry.Event
+		if entry.From != "" || entry.To != "" {
+			line += " " + entry.From + " -> " + entry.To
+		}
+		if entry.Detail != "" {
+			line += ": " + entry.Detail
+		}
+		history = append(history, line)
+	}
+	for _, session := range task.Sessions {
+		sessions = append(sessions, fmt.Sprintf("%s %s %s %s", timestamp(session.StartedAt), session.Agent, session.Kind, session.ID))
+	}
+	for _, comment := range task.Comments {
+		comments = append(comments, fmt.Sprintf("%s %s: %s", timestamp(comment.CreatedAt), comment.Author, comment.Text))
+	}
+
+	return []string{
+		task.ID, task.Title, task.Description, task.ColumnID, column, strconv.Itoa(task.Order),
+		task.Priority.String(), taskIssueType(task), strings.Join(taskLabels(task), ", "),
+		task.Assignee, task.Estimate, task.DueDate, timestamp(task.CreatedAt), timestamp(task.UpdatedAt),
+		agentType, agentStatus, agentSession, agentStarted,
+		strings.Join(task.BlockedBy, ", "), strings.Join(task.Blocking, ", "),
+		strconv.FormatBool(task.IsReady), strconv.FormatBool(task.CriticalPath),
+		git.Worktree, git.Branch, git.BaseBranch, prNumber, git.PRStatus, git.PRUrl,
+		task.Parent, task.ExternalID, task.Resolution,
+		strings.Join(history, "\n"), strings.Join(sessions, "\n"), strings.Join(comments, "\n"),
+	}
+}
+
+// htmlExportTemplate is the self-contained HTML snapshot (no external assets)
+var htmlExportTemplate = template.Must(template.New("board").Funcs(template.FuncMap{
+	"priority":   priorityShortLabel,
+	"type":       taskIssueType,
+	"labels":     taskLabels,
+	"labelColor": func(label string) string { return ansiHexColor(labelColor(label)) },
+	"join":       strings.Join,
+	"lower":      strings.ToLower,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Board.Name}}</title>
+<style>
+body { margin: 0; padding: 24px; background: #1e1e1e; color: #d0d0d0; font: 14px/1.4 system-ui, sans-serif; }
+h1 { margin: 0 0 4px; color: #5fd700; font-size: 20px; }
+.meta { color: #808080; margin-bottom: 20px; }
+.board { display: flex; gap: 16px; align-items: flex-start; overflow-x: auto; }
+.column { flex: 0 0 260px; background: #262626; border-radius: 8px; padding: 12px; }
+.column h2 { margin: 0 0 10px; font-size: 14px; color: #87d7ff; }
+.card { background: #303030; border-radius: 6px; padding: 8px 10px; margin-bottom: 8px; border-left: 3px solid #5f87d7; }
+.card.p0 { border-left-color: #ff5f5f; } .card.p1 { border-left-color: #ff8700; } .card.p3 { border-left-color: #767676; }
+.card.done .title { text-decoration: line-through; color: #a0a0a0; }
+.title { font-weight: 600; }
+.info { color: #949494; font-size: 12px; margin-top: 4px; }
+.chip { display: inline-block; border-radius: 4px; padding: 0 5px; margin: 4px 4px 0 0; font-size: 12px; background: #3a3a3a; }
+</style>
+</head>
+<body>
+<h1>{{.Board.Name}}</h1>
+<div class="meta">Exported {{.At.Format "2006-01-02 15:04"}}{{if .Filter}} · filter: {{.Filter}}{{end}} · {{.Count}} cards</div>
+<div class="board">
+{{- range .Columns}}
+<section class="column">
+<h2>{{.Title}} ({{len .Tasks}})</h2>
+{{- $done := .Done}}
+{{- range .Tasks}}
+<div class="card {{priority .Priority | lower}}{{if $done}} done{{end}}">
+<div class="title">{{.Title}}</div>
+<div class="info">{{.ID}} · {{priority .Priority}}{{with type .}} · {{.}}{{end}}{{with .Assignee}} · @{{.}}{{end}}{{with .DueDate}} · due {{.}}{{end}}{{with .Estimate}} · ~{{.}}{{end}}</div>
+{{- with labels .}}<div>{{range .}}<span class="chip" style="color: {{labelColor .}}">{{.}}</span>{{end}}</div>{{end}}
+{{- with .BlockedBy}}<div class="info">Blocked by {{join . ", "}}</div>{{end}}
+{{- with .Resolution}}<div class="info">{{.}}</div>{{end}}
+</div>
+{{- end}}
+</section>
+{{- end}}
+</div>
+</body>
+</html>
+`))
+
+// writeHTMLExport writes a static, self-contained snapshot of the board
+func writeHTMLExport(w io.Writer, export *boardExport) error {
+	return htmlExportTemplate.Execute(w, map[string]any{
+		"Board":   export.board,
+		"Columns": export.columns,
+		"Filter":  export.filter,
+		"At":      export.at,
+		"Count":   export.taskCount(),
+	})
+}
+
+// ansiHexColor converts a 256-color palette color to #rrggbb for HTML
+func ansiHexColor(color lipgloss.Color) string {
+	n, err := strconv.Atoi(string(color))
+	if err != nil || n < 16 || n > 255 {
+		return "#d0d0d0"
+	}
+	if n >= 232 {
+		level := 8 + (n-232)*10
+		return fmt.Sprintf("#%02x%02x%02x", level, level, level)
+	}
+	steps := []int{0, 95, 135, 175, 215, 255}
+	n -= 16
+	return fmt.Sprintf("#%02x%02x%02x", steps[n/36], steps[n/6%6], steps[n%6])
+}
+
+// writeICSExport writes an all-day event for every task with a due date
+func writeICSExport(w io.Writer, export *boardExport) error {
+	var b strings.Builder
+	line := func(text string) { b.WriteString(foldICSLine(text) + "\r\n") }
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//ai-kanban-board//tui//EN")
+	line("CALSCALE:GREGORIAN")
+	line("X-WR-CALNAME:" + icsEscape(export.board.Name))
+	stamp := export.at.UTC().Format("20060102T150405Z")
+	for _, col := range export.columns {
+		for _, task := range col.Tasks {
+			due, err := time.Parse("2006-01-02", task.DueDate)
+			if err != nil {
+				continue // No due date (or one that isn't a date)
+			}
+			description := fmt.Sprintf("%s · %s · %s", task.ID, col.Title, priorityShortLabel(task.Priority))
+			if task.Description != "" {
+				description += "\n\n" + task.Description
+			}
+			line("BEGIN:VEVENT")
+			line("UID:" + icsEscape(task.ID) + "@ai-kanban-board")
+			line("DTSTAMP:" + stamp)
+			line("DTSTART;VALUE=DATE:" + due.Format("20060102"))
+			line("DTEND;VALUE=DATE:" + due.AddDate(0, 0, 1).Format("20060102"))
+			summary := task.Title
+			if col.Done {
+				summary = "✓ " + summary
+			}
+			line("SUMMARY:" + icsEscape(summary))
+			line("DESCRIPTION:" + icsEscape(description))
+			if labels := taskLabels(task); len(labels) > 0 {
+				categories := make([]string, len(labels))
+				for i, label := range labels {
+					categories[i] = icsEscape(label)
+				}
+				line("CATEGORIES:" + strings.Join(categories, ","))
+			}
+			line("END:VEVENT")
+		}
+	}
+	line("END:VCALENDAR")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// icsEscape escapes iCalendar text values
+func icsEscape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(text)
+}
+
+// foldICSLine folds content lines longer than 75 octets, as RFC 5545 asks
+func foldICSLine(text string) string {
+	var b strings.Builder
+	limit := 75
+	for len(text) > limit {
+		cut := limit
+		for cut > 0 && (text[cut]&0xC0) == 0x80 {
+			cut-- // Don't split a UTF-8 sequence
+		}
+		b.WriteString(text[:cut] + "\r\n ")
+		text = text[cut:]
+		limit = 74 // Continuation lines start with a space
+	}
+	b.WriteString(text)
+	return b.String()
+}
+
+// exportBoard renders an export in a format
+func exportBoard(format exportFormat, export *boardExport) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := format.write(&buf, export); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// exportPath returns the default export file for the board being shown
+func (m Model) exportPath(format exportFormat) string {
+	name := "beads"
+	dir := "."
+	if !m.isBeadsBackend() && m.boardFile != "" {
+		dir = filepath.Dir(m.boardFile)
+		name = strings.TrimSuffix(filepath.Base(m.boardFile), filepath.Ext(m.boardFile))
+	}
+	return filepath.Join(dir, name+"-export."+format.key)
+}
+
+// openExportPrompt asks for the export format and file
+func (m *Model) openExportPrompt() {
+	m.exportFormat = 0
+	m.exportInput = textinput.New()
+	m.exportInput.CharLimit = 300
+	m.exportInput.Width = 46
+	m.exportInput.SetValue(m.exportPath(exportFormats[0]))
+	m.exportInput.Focus()
+	m.exportActive = true
+}
+
+// cycleExportFormat picks another format, following it in the file name
+// unless the name was edited
+func (m *Model) cycleExportFormat(delta int) {
+	current := exportFormats[m.exportFormat]
+	m.exportFormat = (m.exportFormat + delta + len(exportFormats)) % len(exportFormats)
+	next := exportFormats[m.exportFormat]
+	path := m.exportInput.Value()
+	if strings.HasSuffix(path, "."+current.key) {
+		m.exportInput.SetValue(strings.TrimSuffix(path, current.key) + next.key)
+		m.exportInput.CursorEnd()
+	}
+}
+
+// saveExport writes the filtered board to the chosen file
+func (m *Model) saveExport() {
+	path := strings.TrimSpace(m.exportInput.Value())
+	if path == "" {
+		m.statusMessage = "Enter a file to export to"
+		return
+	}
+	m.exportActive = false
+
+	format := exportFormats[m.exportFormat]
+	export := newBoardExport(m.board, m.taskMatchesFilter, m.filterText)
+	data, err := exportBoard(format, export)
+	if err == nil {
+		err = os.WriteFile(path, data, 0644)
+	}
+	if err != nil {
+		m.statusMessage = "Export failed: " + err.Error()
+		return
+	}
+	m.statusMessage = fmt.Sprintf("Exported %d cards to %s", export.taskCount(), path)
+}
+
+// handleExportKeyMsg handles keyboard input while the export prompt is open
+func (m Model) handleExportKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.exportActive = false
+		return m, nil
+	case "enter":
+		m.saveExport()
+		return m, nil
+	case "tab", "down":
+		m.cycleExportFormat(1)
+		return m, nil
+	case "shift+tab", "up":
+		m.cycleExportFormat(-1)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.exportInput, cmd = m.exportInput.Update(msg)
+	return m, cmd
+}
+
+// renderExportPrompt renders the export prompt overlay
+func (m Model) renderExportPrompt(background string) string {
+	var content strings.Builder
+	content.WriteString(styleDetailTitle.Render("Export board"))
+	content.WriteString("\n\n")
+
+	content.WriteString(styleDetailLabel.Render("Format:"))
+	content.WriteString("\n")
+	for i, format := range exportFormats {
+		if i == m.exportFormat {
+			content.WriteString(styleFormSelected.Render(format.label))
+		} else {
+			content.WriteString(styleFormOption.Render(format.label))
+		}
+		if i < len(exportFormats)-1 {
+			content.WriteString("  ")
+		}
+	}
+	content.WriteString("\n\n")
+	content.WriteString(styleDetailLabel.Render("File:"))
+	content.WriteString("\n")
+	content.WriteString(m.exportInput.View())
+	content.WriteString("\n\n")
+	if m.filterText != "" || m.epicFocus != "" {
+		content.WriteString(styleSubdued.Render("Only cards matching the current filter are exported"))
+		content.WriteString("\n\n")
+	}
+	content.WriteString(styleSubdued.Render("Tab: Format | Enter: Export | Esc: Cancel"))
+
+	overlay := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorPrimary).
+		Padding(1, 2).
+		Width(60).
+		Render(content.String())
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay)
+}
+
+// runExport implements the export subcommand
+func runExport(args []string) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	backendOpts := registerBackendFlags(fs)
+	formatKey := fs.String("format", "md", "Export format: md, csv, html or ics")
+	filter := fs.String("filter", "", "Only export cards matching this filter (same syntax as /, e.g. \"#bug login\")")
+	output := fs.String("o", "", "File to write (default: stdout)")
+	all := fs.Bool("all", false, "Include closed beads issues")
+	fs.Parse(args)
+
+	format, ok := findExportFormat(*formatKey)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown format %q (md, csv, html or ics)\n", *formatKey)
+		os.Exit(2)
+	}
+
+	backend := backendOpts.openBackend()
+	if beads, ok := backend.(*BeadsBackend); ok {
+		beads.showAll = *all
+	}
+	board, err := backend.LoadBoard()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading board: %v\n", err)
+		os.Exit(1)
+	}
+
+	match := func(task *Task) bool { return matchesFilterText(task, *filter) }
+	data, err := exportBoard(format, newBoardExport(board, match, *filter))
+	if err == nil {
+		if *output == "" {
+			_, err = os.Stdout.Write(data)
+		} else {
+			err = os.WriteFile(*output, data, 0644)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error exporting: %v\n", err)
+		os.Exit(1)
+	}
+}
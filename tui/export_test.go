@@ -0,0 +1,39 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExportFormats(t *testing.T) {
+	board := &Board{Name: "Sprint", Columns: []Column{{ID: "todo", Title: "To Do"}, {ID: "done", Title: "Done"}}}
+	open := &Task{ID: "t-1", Title: "Ship it, now; really", ColumnID: "todo", Priority: PriorityMedium, Labels: []string{"bug", "ui", "a,b"}, DueDate: "2026-03-02",
+		Description: strings.Repeat("long ", 30)}
+	closed := &Task{ID: "t-2", Title: "Old", ColumnID: "done", Labels: []string{"ops"}}
+	board.Tasks = []*Task{open, closed}
+	populateColumnTasks(board)
+
+	all := newBoardExport(board, func(*Task) bool { return true }, "")
+	data, err := exportBoard(exportFormats[0], all)
+	if err != nil {
+		t.Fatal(err)
+	}
+	md := string(data)
+	if !strings.Contains(md, "## To Do (1)\n\n- [ ] **Ship it, now; really** `t-1` P2 bug `#ui` `#a,b` due 2026-03-02") ||
+		!strings.Contains(md, "- [x] **Old**") {
+		t.Errorf("markdown:\n%s", md)
+	}
+
+	format, _ := findExportFormat("ics")
+	data, _ = exportBoard(format, newBoardExport(board, func(task *Task) bool { return matchesFilterText(task, "#ui") }, "#ui"))
+	ics := string(data)
+	if strings.Count(ics, "BEGIN:VEVENT") != 1 || !strings.Contains(ics, "SUMMARY:Ship it\\, now\\; really\r\n") ||
+		!strings.Contains(ics, "DTSTART;VALUE=DATE:20260302\r\n") || !strings.Contains(ics, "CATEGORIES:ui,a\\,b\r\n") {
+		t.Errorf("ics:\n%s", ics)
+	}
+	for _, line := range strings.Split(ics, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("unfolded line %q", line)
+		}
+	}
+}
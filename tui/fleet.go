@@ -0,0 +1,227 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// fleet.go - Agent fleet view
+// Lists every task with a running or paused agent so parallel agents can be
+// supervised from one screen; agents started by this TUI can be paused,
+// resumed and killed from here
+
+// fleetTickInterval is how often the fleet view refreshes elapsed times
+const fleetTickInterval = time.Second
+
+// fleetTickMsg refreshes the fleet view while it is open
+type fleetTickMsg struct{}
+
+// fleetTick schedules the next fleet view refresh
+func fleetTick() tea.Cmd {
+	return tea.Tick(fleetTickInterval, func(time.Time) tea.Msg {
+		return fleetTickMsg{}
+	})
+}
+
+// fleetEntry is one row of the fleet view
+type fleetEntry struct {
+	Task    *Task
+	Column  string
+	Managed bool // Started by this TUI, so it can be paused/resumed/killed
+}
+
+// fleetEntries returns the tasks with a running or paused agent, in board order
+func (m Model) fleetEntries() []fleetEntry {
+	var entries []fleetEntry
+	for _, col := range m.board.Columns {
+		for _, task := range col.Tasks {
+			if task.Agent == nil {
+				continue
+			}
+			if task.Agent.Status != AgentRunning && task.Agent.Status != AgentPaused {
+				continue
+			}
+			entries = append(entries, fleetEntry{
+				Task:    task,
+				Column:  col.Title,
+				Managed: m.agents != nil && m.agents.isRunning(task.ID),
+			})
+		}
+	}
+	return entries
+}
+
+// selectedFleetEntry returns the highlighted fleet entry, if any
+func (m Model) selectedFleetEntry() (fleetEntry, bool) {
+	entries := m.fleetEntries()
+	if m.fleetIndex < 0 || m.fleetIndex >= len(entries) {
+		return fleetEntry{}, false
+	}
+	return entries[m.fleetIndex], true
+}
+
+// openFleetView switches to the fleet view and starts the refresh tick
+func (m *Model) openFleetView() tea.Cmd {
+	m.previousView = m.viewMode
+	m.viewMode = ViewFleet
+	m.fleetIndex = 0
+	return fleetTick()
+}
+
+// formatElapsed formats a duration as a compact clock, e.g. 4m05s or 1h02m
+func formatElapsed(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d >= time.Hour {
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+}
+
+// setFleetAgentStatus pauses or resumes the selected agent
+func (m *Model) setFleetAgentStatus(status AgentStatus) {
+	entry, ok := m.selectedFleetEntry()
+	if !ok {
+		return
+	}
+	if !entry.Managed {
+		m.statusMessage = fmt.Sprintf("%s was not started by this TUI", entry.Task.ID)
+		return
+	}
+	if entry.Task.Agent.Status == status {
+		return
+	}
+
+	var err error
+	if status == AgentPaused {
+		err = m.agents.pause(entry.Task.ID)
+	} else {
+		err = m.agents.resume(entry.Task.ID)
+	}
+	if err != nil {
+		m.statusMessage = err.Error()
+		return
+	}
+
+	entry.Task.Agent.Status = status
+	if m.backend != nil {
+		if err := m.backend.UpdateTask(entry.Task); err != nil {
+			m.statusMessage = fmt.Sprintf("%s is %s, but that wasn't saved: %v", entry.Task.ID, status, err)
+		}
+	}
+}
+
+// killFleetAgent stops the selected agent; its exit is recorded as a failure
+// without running auto-advance rules
+func (m *Model) killFleetAgent() {
+	entry, ok := m.selectedFleetEntry()
+	if !ok {
+		return
+	}
+	if !entry.Managed {
+		m.statusMessage = fmt.Sprintf("%s was not started by this TUI", entry.Task.ID)
+		return
+	}
+	if err := m.agents.kill(entry.Task.ID); err != nil {
+		m.statusMessage = err.Error()
+	}
+}
+
+// jumpToFleetTask selects the highlighted task on the board
+func (m *Model) jumpToFleetTask() {
+	entry, ok := m.selectedFleetEntry()
+	if !ok {
+		return
+	}
+	for i, col := range m.board.Columns {
+		for j, task := range col.Tasks {
+			if task == entry.Task {
+				m.selectedColumn = i
+				m.selectedTask = j
+			}
+		}
+	}
+	m.viewMode = ViewBoard
+	m.ensureSelectedColumnVisible()
+	m.updateScrollOffset()
+	m.fetchIssueDetails()
+}
+
+// renderFleetView renders the agent fleet view
+func (m Model) renderFleetView() string {
+	var sections []string
+
+	entries := m.fleetEntries()
+	running := 0
+	for _, entry := range entries {
+		if entry.Task.Agent.Status == AgentRunning {
+			running++
+		}
+	}
+
+	title := fmt.Sprintf("Agent Fleet - %d running, %d paused", running, len(entries)-running)
+	sections = append(sections, styleTitle.Width(m.width).Render(title))
+
+	var content strings.Builder
+	if len(entries) == 0 {
+		content.WriteString(styleSubdued.Render("No running or paused agents"))
+		content.WriteString("\n")
+	}
+
+	// Columns: status, agent type, elapsed, task, column, last log line
+	logWidth := m.width - 78
+	if logWidth < 10 {
+		logWidth = 10
+	}
+	for i, entry := range entries {
+		agent := entry.Task.Agent
+
+		elapsed := "-"
+		if agent.StartedAt != nil {
+			elapsed = formatElapsed(time.Since(*agent.StartedAt))
+		}
+
+		lastLog := ""
+		if len(agent.Logs) > 0 {
+			lastLog = sanitizeLine(agent.Logs[len(agent.Logs)-1])
+		}
+
+		marker := " "
+		if entry.Managed {
+			marker = "●" // Started by this TUI
+		}
+
+		line := fmt.Sprintf("%s %-12s %7s  %-30s %-14s %s",
+			marker,
+			truncateText(string(agent.Type), 12),
+			elapsed,
+			truncateText(sanitizeLine(entry.Task.Title), 30),
+			truncateText(entry.Column, 14),
+			truncateText(lastLog, logWidth))
+
+		row := renderCompactAgentBadge(agent) + " "
+		if i == m.fleetIndex {
+			row += styleFormSelected.Render(line)
+		} else {
+			row += styleDetailValue.Render(line)
+		}
+		content.WriteString(row)
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	content.WriteString(styleSubdued.Render("● started by this TUI"))
+	content.WriteString("\n\n")
+	content.WriteString(styleSubdued.Render("j/k: Select | p: Pause | r: Resume | x: Kill | Enter: Show on board | Esc/F: Back"))
+
+	sections = append(sections, lipgloss.NewStyle().Padding(1, 2).Width(m.width).Render(content.String()))
+
+	view := lipgloss.JoinVertical(lipgloss.Left, sections...)
+	if m.statusMessage != "" {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, styleStatus.Width(m.width).Render(m.statusMessage))
+	}
+	return view
+}
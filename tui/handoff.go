@@ -0,0 +1,237 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"unicode"
+)
+
+// handoff.go - Safe context handoff to agents
+// Task text comes from other people's issues, so it never goes through a
+// shell: commands are built as argv, text is stripped of control characters,
+// and launchers that hand the command to another program (tmux, zellij, a
+// custom terminal) get a short `launch-spec <file>` command instead, with the
+// real argv in a private temp file read back by this binary
+
+// Context sections that can be listed in a board's context.include
+const (
+	contextDescription  = "description"
+	contextDependencies = "dependencies"
+	contextHistory      = "history"
+	contextFiles        = "files"
+	contextGit          = "git"
+)
+
+// defaultContextInclude is used when a board doesn't configure its context bundle
+var defaultContextInclude = []string{contextDescription, contextDependencies, contextHistory, contextFiles, contextGit}
+
+// Default limits for the context bundle
+const (
+	defaultContextHistory = 5
+	defaultContextFiles   = 30
+)
+
+// ContextConfig selects what goes into the task context handed to agents
+type ContextConfig struct {
+	Include      []string `yaml:"include,omitempty" json:"include,omitempty"`            // Sections, see defaultContextInclude
+	HistoryLimit int      `yaml:"history_limit,omitempty" json:"historyLimit,omitempty"` // Most recent history entries
+	MaxFiles     int      `yaml:"max_files,omitempty" json:"maxFiles,omitempty"`         // Changed files listed from the worktree
+}
+
+// includes reports whether a section is part of the bundle (nil config uses defaults)
+func (c *ContextConfig) includes(section string) bool {
+	include := defaultContextInclude
+	if c != nil && len(c.Include) > 0 {
+		include = c.Include
+	}
+	return containsString(include, section)
+}
+
+// historyLimit returns how many history entries to include
+func (c *ContextConfig) historyLimit() int {
+	if c != nil && c.HistoryLimit > 0 {
+		return c.HistoryLimit
+	}
+	return defaultContextHistory
+}
+
+// maxFiles returns how many worktree files to include
+func (c *ContextConfig) maxFiles() int {
+	if c != nil && c.MaxFiles > 0 {
+		return c.MaxFiles
+	}
+	return defaultContextFiles
+}
+
+// sanitizeLine makes untrusted text safe to show on one line: newlines and
+// tabs become spaces, other control characters (including ANSI escapes) are dropped
+func sanitizeLine(s string) string {
+	return strings.Join(strings.Fields(sanitizeText(s)), " ")
+}
+
+// sanitizeText drops control characters from untrusted multi-line text,
+// keeping newlines and tabs
+func sanitizeText(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' {
+			return r
+		}
+		if r == '\r' || unicode.IsControl(r) || r == unicode.ReplacementChar {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// escapeTmuxFormat stops tmux from expanding #{...} and #(...) in text passed
+// to options that are formats (display-popup -T, -d)
+func escapeTmuxFormat(s string) string {
+	return strings.ReplaceAll(s, "#", "##")
+}
+
+// worktreeFiles lists files changed in a worktree: committed changes against
+// the base branch (when known) followed by uncommitted ones
+func worktreeFiles(git *GitInfo, max int) []string {
+	if git == nil || git.Worktree == "" {
+		return nil
+	}
+
+	var files []string
+	seen := make(map[string]bool)
+	add := func(output []byte) {
+		for _, line := range strings.Split(string(output), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || seen[line] || len(files) >= max {
+				continue
+			}
+			seen[line] = true
+			files = append(files, sanitizeLine(line))
+		}
+	}
+
+	if git.BaseBranch != "" {
+		out, err := exec.Command("git", "-C", git.Worktree, "diff", "--name-only", git.BaseBranch+"...HEAD", "--").Output()
+		if err == nil {
+			add(out)
+		}
+	}
+
+	// Porcelain lines are "XY path"; renames are "XY old -> new"
+	out, err := exec.Command("git", "-C", git.Worktree, "status", "--porcelain").Output()
+	if err == nil {
+		var paths []string
+		for _, line := range strings.Split(string(out), "\n") {
+			if len(line) < 4 {
+				continue
+			}
+			path := line[3:]
+			if _, after, ok := strings.Cut(path, " -> "); ok {
+				path = after
+			}
+			paths = append(paths, strings.Trim(path, "\""))
+		}
+		add([]byte(strings.Join(paths, "\n")))
+	}
+
+	return files
+}
+
+// launchSpecFile is what `launch-spec` reads back: the command to run and where
+type launchSpecFile struct {
+	Argv []string `json:"argv"`
+	Dir  string   `json:"dir,omitempty"`
+}
+
+// handoffArgv writes the spec's command to a private temp file and returns a
+// `launch-spec` command for it along with the file, so launchers never pass
+// task text on to another program's command line. Fails if this binary's path
+// is unknown rather than handing over the task text
+func handoffArgv(spec LaunchSpec) (argv []string, specFile string, err error) {
+	self, err := os.Executable()
+	if err != nil {
+		return nil, "", fmt.Errorf("can't hand the chat over without this program's path: %w", err)
+	}
+
+	specFile, err = writeLaunchSpec(launchSpecFile{Argv: spec.Argv, Dir: spec.Dir})
+	if err != nil {
+		return nil, "", err
+	}
+	return []string{self, "launch-spec", specFile}, specFile, nil
+}
+
+// writeLaunchSpec writes a launch spec to a temp file readable only by the user
+func writeLaunchSpec(spec launchSpecFile) (string, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.CreateTemp("", "ai-kanban-launch-*.json")
+	if err != nil {
+		return "", fmt.Errorf("failed to write launch spec: %w", err)
+	}
+	defer f.Close()
+
+	// CreateTemp already uses 0600; keep it explicit
+	if err := f.Chmod(0600); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	if _, err := f.Write(data); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// readLaunchSpec reads and deletes a launch spec file
+func readLaunchSpec(path string) (launchSpecFile, error) {
+	var spec launchSpecFile
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return spec, err
+	}
+	os.Remove(path) // One use only
+
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return spec, fmt.Errorf("invalid launch spec: %w", err)
+	}
+	if len(spec.Argv) == 0 {
+		return spec, errors.New("launch spec has no command")
+	}
+	return spec, nil
+}
+
+// runLaunchSpec implements the internal `launch-spec <file>` subcommand used
+// by launchers: it runs the command from the file attached to this terminal
+func runLaunchSpec(args []string) {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "usage: launch-spec <file>")
+		os.Exit(2)
+	}
+
+	spec, err := readLaunchSpec(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	cmd := exec.Command(spec.Argv[0], spec.Argv[1:]...)
+	cmd.Dir = spec.Dir
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.ExitCode())
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,557 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// import.go - Importers for todo.txt, Taskwarrior and GitHub issues
+// ai-kanban-tui import reads a todo.txt file, a `task export` JSON dump or
+// `gh issue list --json ...` output and creates a card per item through the
+// backend. Every card keeps the item's external ID (external_ref in beads),
+// so importing the same file again skips what is already on the board.
+// Finished items are skipped unless --include-done is given
+
+// importItem is a task read from an import file
+type importItem struct {
+	fields      captureFields // Title, type, priority, labels, assignee, due date, external ID
+	description string
+	status      string   // StatusOpen, StatusInProgress or StatusClosed
+	resolution  string   // For closed items
+	blockedBy   []string // External IDs of blockers
+	comments    []Comment
+}
+
+// importFormat is a file format tasks can be imported from
+type importFormat struct {
+	key   string // --format value
+	label string
+	read  func(data []byte) ([]importItem, error)
+}
+
+// importFormats are the import formats
+var importFormats = []importFormat{
+	{key: "todotxt", label: "todo.txt", read: readTodoTxt},
+	{key: "taskwarrior", label: "Taskwarrior JSON (task export)", read: readTaskwarrior},
+	{key: "github", label: "GitHub issues (gh issue list --json)", read: readGitHubIssues},
+}
+
+// findImportFormat returns the import format with a key
+func findImportFormat(key string) (importFormat, bool) {
+	for _, format := range importFormats {
+		if format.key == strings.ToLower(key) {
+			return format, true
+		}
+	}
+	return importFormat{}, false
+}
+
+// detectImportFormat guesses a file's format: JSON with uuid fields is
+// Taskwarrior, other JSON is GitHub, anything else todo.txt
+func detectImportFormat(data []byte) importFormat {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || (trimmed[0] != '[' && trimmed[0] != '{') {
+		return importFormats[0]
+	}
+	if bytes.Contains(trimmed, []byte(`"uuid"`)) {
+		return importFormats[1]
+	}
+	return importFormats[2]
+}
+
+// newImportItem returns an open item with the default priority
+func newImportItem(externalID string) importItem {
+	item := importItem{status: StatusOpen}
+	item.fields.priority = PriorityMedium
+	item.fields.externalID = externalID
+	return item
+}
+
+// importLabel turns a name into a label (labels can't contain spaces)
+func importLabel(name string) string {
+	return strings.Join(strings.Fields(name), "-")
+}
+
+// readTodoTxt reads todo.txt lines: x marks done, (A)-(Z) priority, +project
+// and @context become labels (context:<name>), due: the due date; other
+// key:value tags go to the description
+func readTodoTxt(data []byte) ([]importItem, error) {
+	var items []importItem
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		words := strings.Fields(scanner.Text())
+		if len(words) == 0 {
+			continue
+		}
+
+		done := words[0] == "x"
+		if done {
+			words = words[1:]
+		}
+		priority := ""
+		if len(words) > 0 && len(words[0]) == 3 && words[0][0] == '(' && words[0][2] == ')' && words[0][1] >= 'A' && words[0][1] <= 'Z' {
+			priority, words = words[0][1:2], words[1:]
+		}
+		for len(words) > 0 && isTodoTxtDate(words[0]) {
+			words = words[1:] // Completion and creation dates
+		}
+
+		// The ID covers the text only, so finishing or reprioritizing an item
+		// doesn't make it new
+		sum := sha1.Sum([]byte(strings.Join(words, " ")))
+		item := newImportItem("todotxt:" + hex.EncodeToString(sum[:6]))
+		var title, tags []string
+		for _, word := range words {
+			key, value, isTag := strings.Cut(word, ":")
+			switch {
+			case strings.HasPrefix(word, "+") && len(word) > 1:
+				item.fields.addLabel(word[1:])
+			case strings.HasPrefix(word, "@") && len(word) > 1:
+				item.fields.addLabel("context:" + word[1:])
+			case isTag && key == "due" && isTodoTxtDate(value):
+				item.fields.due = value
+			case isTag && key == "pri" && len(value) == 1:
+				priority = strings.ToUpper(value) // Kept on done items by some clients
+			case isTag && key != "" && value != "" && !strings.HasPrefix(value, "//"):
+				tags = append(tags, word)
+			default:
+				title = append(title, word)
+			}
+		}
+		item.fields.title = strings.Join(title, " ")
+		if item.fields.title == "" {
+			continue
+		}
+		if priority != "" {
+			item.fields.priority = todoTxtPriority(priority[0])
+		}
+		if len(tags) > 0 {
+			item.description = "todo.txt tags: " + strings.Join(tags, " ")
+		}
+		if done {
+			item.status = StatusClosed
+		}
+		items = append(items, item)
+	}
+	return items, scanner.Err()
+}
+
+// isTodoTxtDate reports whether s is a YYYY-MM-DD date
+func isTodoTxtDate(s string) bool {
+	_, err := time.Parse("2006-01-02", s)
+	return err == nil
+}
+
+// todoTxtPriority maps (A) to P0, (B) to P1, (C) to P2 and the rest to P3
+func todoTxtPriority(p byte) Priority {
+	if p > 'D' {
+		p = 'D'
+	}
+	return beadsPriority(int(p - 'A'))
+}
+
+// taskwarriorTask is a task from `task export`
+type taskwarriorTask struct {
+	UUID        string          `json:"uuid"`
+	Description string          `json:"description"`
+	Status      string          `json:"status"` // pending, waiting, completed, deleted, recurring
+	Project     string          `json:"project"`
+	Tags        []string        `json:"tags"`
+	Priority    string          `json:"priority"` // H, M, L
+	Due         string          `json:"due"`
+	Start       string          `json:"start"`
+	Depends     json.RawMessage `json:"depends"` // "uuid,uuid" before 2.6, a list since
+	Annotations []struct {
+		Entry       string `json:"entry"`
+		Description string `json:"description"`
+	} `json:"annotations"`
+}
+
+// taskwarriorTime is the date format of task export
+const taskwarriorTime = "20060102T150405Z"
+
+// readTaskwarrior reads `task export` output (a JSON array, or one object per
+// line from old versions). Projects become project:<name> labels, tags
+// labels, annotations comments and depends blockers
+func readTaskwarrior(data []byte) ([]importItem, error) {
+	var tasks []taskwarriorTask
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := decoder.Decode(&tasks); err != nil {
+			return nil, err
+		}
+	} else {
+		for {
+			var task taskwarriorTask
+			if err := decoder.Decode(&task); errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				return nil, err
+			}
+			tasks = append(tasks, task)
+		}
+	}
+
+	var items []importItem
+	for _, task := range tasks {
+		if task.Status == "deleted" || task.Status == "recurring" || task.Description == "" {
+			continue // Recurring templates spawn the pending tasks
+		}
+		item := newImportItem("taskwarrior:" + task.UUID)
+		item.fields.title = task.Description
+		switch task.Priority {
+		case "H":
+			item.fields.priority = PriorityHigh
+		case "L":
+			item.fields.priority = PriorityLow
+		}
+		if task.Project != "" {
+			item.fields.addLabel("project:" + importLabel(task.Project))
+		}
+		for _, tag := range task.Tags {
+			item.fields.addLabel(importLabel(tag))
+		}
+		if due, err := time.Parse(taskwarriorTime, task.Due); err == nil {
+			item.fields.due = due.Local().Format("2006-01-02")
+		}
+		switch {
+		case task.Status == "completed":
+			item.status = StatusClosed
+		case task.Start != "":
+			item.status = StatusInProgress
+		}
+		for _, annotation := range task.Annotations {
+			at, _ := time.Parse(taskwarriorTime, annotation.Entry)
+			item.comments = append(item.comments, Comment{Author: "taskwarrior", Text: annotation.Description, CreatedAt: at})
+		}
+		for _, uuid := range taskwarriorDepends(task.Depends) {
+			item.blockedBy = append(item.blockedBy, "taskwarrior:"+uuid)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// taskwarriorDepends reads depends in either of its formats
+func taskwarriorDepends(raw json.RawMessage) []string {
+	var list []string
+	if json.Unmarshal(raw, &list) == nil {
+		return list
+	}
+	var joined string
+	if json.Unmarshal(raw, &joined) == nil && joined != "" {
+		return strings.Split(joined, ",")
+	}
+	return nil
+}
+
+// githubIssue is an issue from `gh issue list --json ...`
+type githubIssue struct {
+	Number      int    `json:"number"`
+	Title       string `json:"title"`
+	Body        string `json:"body"`
+	State       string `json:"state"`       // OPEN, CLOSED
+	StateReason string `json:"stateReason"` // COMPLETED, NOT_PLANNED
+	URL         string `json:"url"`
+	Labels      []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Assignees []struct {
+		Login string `json:"login"`
+	} `json:"assignees"`
+	Milestone *struct {
+		Title string `json:"title"`
+	} `json:"milestone"`
+	Comments []struct {
+		Author struct {
+			Login string `json:"login"`
+		} `json:"author"`
+		Body      string    `json:"body"`
+		CreatedAt time.Time `json:"createdAt"`
+	} `json:"comments"`
+}
+
+// githubLabelTypes maps GitHub's default labels to issue types
+var githubLabelTypes = map[string]string{"bug": "bug", "enhancement": "feature", "feature": "feature", "epic": issueTypeEpic, "chore": "chore"}
+
+// readGitHubIssues reads `gh issue list --json` output. Labels naming a type
+// (bug, enhancement) set it, p0-p4 and priority: labels the priority, and
+// milestones become milestone:<title> labels
+func readGitHubIssues(data []byte) ([]importItem, error) {
+	var issues []githubIssue
+	if err := json.Unmarshal(data, &issues); err != nil {
+		return nil, err
+	}
+
+	var items []importItem
+	for _, issue := range issues {
+		id := "github:" + issue.URL
+		if issue.URL == "" {
+			id = fmt.Sprintf("github:#%d", issue.Number)
+		}
+		item := newImportItem(id)
+		item.fields.title = issue.Title
+		item.description = issue.Body
+		for _, label := range issue.Labels {
+			name := strings.ToLower(label.Name)
+			if kind, ok := githubLabelTypes[name]; ok && item.fields.issueType == "" {
+				item.fields.issueType = kind
+			} else if priority, ok := githubPriority(name); ok {
+				item.fields.priority = priority
+			} else {
+				item.fields.addLabel(importLabel(label.Name))
+			}
+		}
+		if issue.Milestone != nil && issue.Milestone.Title != "" {
+			item.fields.addLabel("milestone:" + importLabel(issue.Milestone.Title))
+		}
+		if len(issue.Assignees) > 0 {
+			item.fields.assignee = issue.Assignees[0].Login
+		}
+		if strings.EqualFold(issue.State, "closed") {
+			item.status = StatusClosed
+			item.resolution = resolutionKinds[resolutionDone].label
+			if issue.StateReason == "NOT_PLANNED" {
+				item.resolution = resolutionKinds[resolutionWontFix].label
+			}
+		}
+		for _, comment := range issue.Comments {
+			item.comments = append(item.comments, Comment{Author: comment.Author.Login, Text: comment.Body, CreatedAt: comment.CreatedAt})
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// githubPriority reads priority labels: p1, P1, priority: high, priority/critical
+func githubPriority(label string) (Priority, bool) {
+	if len(label) == 2 && label[0] == 'p' && label[1] >= '0' && label[1] <= '4' {
+		return beadsPriority(int(label[1] - '0')), true
+	}
+	rest, ok := strings.CutPrefix(label, "priority")
+	if !ok {
+		return 0, false
+	}
+	switch strings.TrimSpace(strings.TrimLeft(rest, ":/-_ ")) {
+	case "critical", "urgent":
+		return PriorityUrgent, true
+	case "high":
+		return PriorityHigh, true
+	case "medium":
+		return PriorityMedium, true
+	case "low":
+		return PriorityLow, true
+	}
+	return 0, false
+}
+
+// importer creates cards for imported items
+type importer struct {
+	backend     Backend
+	board       *Board
+	column      *Column           // Column for items that aren't done, nil to go by status
+	includeDone bool              // Import finished items into the done column
+	existing    map[string]string // External ID -> task ID of cards already on the board
+}
+
+// newImporter indexes the external IDs already on the board
+func newImporter(backend Backend, board *Board) *importer {
+	imp := &importer{backend: backend, board: board, existing: make(map[string]string)}
+	for _, task := range board.Tasks {
+		if task.ExternalID != "" {
+			imp.existing[task.ExternalID] = task.ID
+		}
+	}
+	return imp
+}
+
+// targetColumn returns the column an item goes to
+func (imp *importer) targetColumn(item importItem) *Column {
+	if imp.column != nil && item.status != StatusClosed {
+		return imp.column
+	}
+	return columnForStatus(imp.backend, imp.board, item.status)
+}
+
+// importItems creates a card for every new item, printing a line per item;
+// with dryRun nothing is created
+func (imp *importer) importItems(items []importItem, dryRun bool, out io.Writer) (created, skipped int, warnings []string, err error) {
+	beads, toBeads := imp.backend.(*BeadsBackend)
+	createdTasks := make(map[string]*Task) // External ID -> new card
+	for _, item := range items {
+		id := item.fields.externalID
+		if taskID := imp.existing[id]; taskID != "" {
+			fmt.Fprintf(out, "  skip  %-12s %s (already imported)\n", taskID, truncateText(sanitizeLine(item.fields.title), 60))
+			skipped++
+			continue
+		}
+		if item.status == StatusClosed && !imp.includeDone {
+			skipped++
+			continue
+		}
+
+		col := imp.targetColumn(item)
+		if dryRun {
+			fmt.Fprintf(out, "  new   %-12s %s\n", truncateText(col.Title, 12), describeImportItem(item))
+			created++
+			continue
+		}
+
+		columnID := col.ID
+		if toBeads && item.status == StatusClosed {
+			columnID = statusColumn(imp.board, StatusOpen).ID // Closed below, with its resolution
+		}
+		task, err := item.fields.createTask(imp.backend, columnID, item.description)
+		if task == nil {
+			return created, skipped, warnings, fmt.Errorf("importing %s: %w", id, err)
+		}
+		if err != nil {
+			warnings = append(warnings, err.Error())
+		}
+		if item.status == StatusClosed {
+			if toBeads {
+				err = beads.CloseTask(task.ID, item.resolution)
+			} else if item.resolution != "" {
+				task.Resolution = item.resolution
+				err = imp.backend.UpdateTask(task)
+			}
+			if err != nil {
+				warnings = append(warnings, err.Error())
+			}
+		}
+		for _, comment := range item.comments {
+			if err := postComment(imp.backend, task, comment); err != nil {
+				warnings = append(warnings, fmt.Sprintf("%s: comment not imported: %v", task.ID, err))
+			}
+		}
+		imp.existing[id] = task.ID
+		createdTasks[id] = task
+		fmt.Fprintf(out, "  new   %-12s %s\n", task.ID, describeImportItem(item))
+		created++
+	}
+
+	// Blockers, once every imported card exists
+	for _, item := range items {
+		task := createdTasks[item.fields.externalID]
+		if task == nil || len(item.blockedBy) == 0 {
+			continue
+		}
+		for _, blocker := range item.blockedBy {
+			if taskID := imp.existing[blocker]; taskID != "" {
+				task.BlockedBy = append(task.BlockedBy, taskID)
+			}
+		}
+		if len(task.BlockedBy) == 0 {
+			continue
+		}
+		task.IsReady = false
+		if err := imp.backend.UpdateTask(task); err != nil {
+			warnings = append(warnings, err.Error())
+		}
+	}
+	return created, skipped, warnings, nil
+}
+
+// describeImportItem summarizes an item: "P1 bug +auth @alice due ... Title"
+func describeImportItem(item importItem) string {
+	fields := item.fields
+	fields.hasPriority = true
+	parts := fields.summary()
+	return strings.Join(append(parts, truncateText(sanitizeLine(fields.title), 60)), " ")
+}
+
+// runImport implements the import subcommand
+func runImport(args []string) {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	backendOpts := registerBackendFlags(fs)
+	formatKey := fs.String("format", "auto", "File format: todotxt, taskwarrior, github or auto")
+	column := fs.String("column", "", "Column ID or title for imported cards (default: by status)")
+	includeDone := fs.Bool("include-done", false, "Also import finished items, into the done column")
+	dryRun := fs.Bool("dry-run", false, "Show what would be imported without creating cards")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: ai-kanban-tui import [flags] FILE (- reads stdin)")
+		fmt.Fprintln(fs.Output(), "  todo.txt, `task export` output or `gh issue list --json number,title,body,labels,assignees,milestone,state,stateReason,url,comments`")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	path := fs.Arg(0)
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	format := detectImportFormat(data)
+	if *formatKey != "auto" {
+		var ok bool
+		if format, ok = findImportFormat(*formatKey); !ok {
+			fmt.Fprintf(os.Stderr, "Unknown format %q (todotxt, taskwarrior or github)\n", *formatKey)
+			os.Exit(2)
+		}
+	}
+	items, err := format.read(data)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading %s as %s: %v\n", filepath.Base(path), format.label, err)
+		os.Exit(1)
+	}
+
+	backend := backendOpts.openBackend()
+	if beads, ok := backend.(*BeadsBackend); ok {
+		beads.showAll = true // Closed issues count for de-duplication
+	}
+	board, err := backend.LoadBoard()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading board: %v\n", err)
+		os.Exit(1)
+	}
+	if len(board.Columns) == 0 {
+		fmt.Fprintln(os.Stderr, "Error: board has no columns")
+		os.Exit(1)
+	}
+
+	imp := newImporter(backend, board)
+	imp.includeDone = *includeDone
+	if *column != "" {
+		if imp.column = resolveColumn(board, *column); imp.column == nil {
+			fmt.Fprintf(os.Stderr, "Unknown column %q\n", *column)
+			os.Exit(1)
+		}
+	}
+
+	verb := "Imported"
+	if *dryRun {
+		verb = "Would import"
+		fmt.Printf("Dry run: %d items read as %s\n\n", len(items), format.label)
+	}
+	created, skipped, warnings, err := imp.importItems(items, *dryRun, os.Stdout)
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+	fmt.Printf("\n%s %d, skipped %d (already imported or done)\n", verb, created, skipped)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v (re-run to continue, imported items are skipped)\n", err)
+		os.Exit(1)
+	}
+}
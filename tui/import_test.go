@@ -0,0 +1,63 @@
+package main
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadTodoTxt(t *testing.T) {
+	data := []byte("(A) 2026-10-01 Call Mom +Family @phone due:2026-10-20 ref:42\nx 2026-10-02 2026-09-30 Pay rent\n\n")
+	if format := detectImportFormat(data); format.key != "todotxt" {
+		t.Fatalf("detected %s", format.key)
+	}
+	items, err := readTodoTxt(data)
+	if err != nil || len(items) != 2 {
+		t.Fatalf("read %d items, %v", len(items), err)
+	}
+	call := items[0]
+	if call.fields.title != "Call Mom" || call.fields.priority != PriorityUrgent || call.fields.due != "2026-10-20" {
+		t.Errorf("call parsed as %+v", call.fields)
+	}
+	if strings.Join(call.fields.labels, ",") != "Family,context:phone" || !strings.Contains(call.description, "ref:42") {
+		t.Errorf("call labels %v, description %q", call.fields.labels, call.description)
+	}
+	if items[1].fields.title != "Pay rent" || items[1].status != StatusClosed {
+		t.Errorf("rent parsed as %q (%s)", items[1].fields.title, items[1].status)
+	}
+}
+
+func TestImportSkipsExisting(t *testing.T) {
+	data := []byte(`[{"number":7,"title":"Crash on start","state":"OPEN","url":"https://github.com/o/r/issues/7","labels":[{"name":"bug"}]},
+{"number":8,"title":"Won't do","state":"CLOSED","stateReason":"NOT_PLANNED","url":"https://github.com/o/r/issues/8"}]`)
+	if format := detectImportFormat(data); format.key != "github" {
+		t.Fatalf("detected %s", format.key)
+	}
+	items, err := readGitHubIssues(data)
+	if err != nil || len(items) != 2 {
+		t.Fatalf("read %d items, %v", len(items), err)
+	}
+
+	backend := NewLocalBackend(filepath.Join(t.TempDir(), "board.yaml"))
+	board := &Board{ID: "board-1", Columns: []Column{{ID: "todo", Title: "To Do"}, {ID: "done", Title: "Done"}}}
+	if err := backend.SaveBoard(board); err != nil {
+		t.Fatal(err)
+	}
+	created, skipped, _, err := newImporter(backend, board).importItems(items, false, io.Discard)
+	if err != nil || created != 1 || skipped != 1 {
+		t.Fatalf("created %d, skipped %d, %v", created, skipped, err)
+	}
+
+	board, err = backend.LoadBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	task := board.Tasks[0]
+	if task.ExternalID != "github:https://github.com/o/r/issues/7" || task.ColumnID != "todo" || taskIssueType(task) != "bug" {
+		t.Errorf("imported %+v", task)
+	}
+	if created, _, _, _ := newImporter(backend, board).importItems(items, false, io.Discard); created != 0 {
+		t.Errorf("re-import created %d cards", created)
+	}
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// labels.go - Label chips and label filters
+// A task's first label is its issue type when it names one; the rest are
+// labels, shown as colored chips on cards and in the detail panel. Labels of
+// the form dimension:value (beads state labels, bd set-state) are grouped by
+// dimension and share the dimension's color. The filter matches labels, and
+// #label / #dimension: terms only match tagged cards
+
+// issueTypes are the issue types a first label can name; beads boards add
+// any other type bd reports (see addIssueType)
+var issueTypes = []string{"task", "bug", "feature", issueTypeEpic, "chore"}
+
+// addIssueType makes a type reported by beads (e.g. decision) known, so it is
+// kept as the card's type instead of being read as a label
+func addIssueType(issueType string) {
+	if issueType != "" && !containsString(issueTypes, issueType) {
+		issueTypes = append(issueTypes, issueType)
+	}
+}
+
+// cycleIssueType returns the issue type step places after current in issueTypes
+func cycleIssueType(current string, step int) string {
+	i := 0
+	for j, issueType := range issueTypes {
+		if issueType == current {
+			i = j
+		}
+	}
+	n := len(issueTypes)
+	return issueTypes[((i+step)%n+n)%n]
+}
+
+// labelChipColors are the chip colors labels hash into
+var labelChipColors = []lipgloss.Color{"75", "114", "176", "215", "117", "183", "150", "210", "147", "180"}
+
+// taskIssueType returns a task's issue type, "" when its first label isn't one
+func taskIssueType(task *Task) string {
+	if len(task.Labels) > 0 && containsString(issueTypes, task.Labels[0]) {
+		return task.Labels[0]
+	}
+	return ""
+}
+
+// taskLabels returns a task's labels without its issue type
+func taskLabels(task *Task) []string {
+	if taskIssueType(task) != "" {
+		return task.Labels[1:]
+	}
+	return task.Labels
+}
+
+// setTaskLabels replaces a task's labels, keeping its issue type (task when
+// it has none) first so no label is taken for the type
+func setTaskLabels(task *Task, labels []string) {
+	issueType := taskIssueType(task)
+	if issueType == "" {
+		issueType = "task"
+	}
+	task.Labels = append([]string{issueType}, labels...)
+}
+
+// parseLabelList reads "a, b c" into labels, dropping duplicates
+func parseLabelList(text string) []string {
+	var labels []string
+	for _, label := range strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ' ' }) {
+		if !containsString(labels, label) {
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
+
+// splitStateLabel splits a dimension:value state label
+func splitStateLabel(label string) (dimension, value string, ok bool) {
+	dimension, value, ok = strings.Cut(label, ":")
+	return dimension, value, ok && dimension != "" && value != ""
+}
+
+// labelColor picks a label's chip color; state labels use their dimension's
+func labelColor(label string) lipgloss.Color {
+	if dimension, _, ok := splitStateLabel(label); ok {
+		label = dimension
+	}
+	h := fnv.New32a()
+	h.Write([]byte(label))
+	return labelChipColors[h.Sum32()%uint32(len(labelChipColors))]
+}
+
+// renderLabelChip renders a label as a chip in the detail panel
+func renderLabelChip(label string) string {
+	return styleLabel.Foreground(labelColor(label)).Render(sanitizeLine(label))
+}
+
+// renderCardChips renders as many label chips as fit in width, then "+N".
+// Cards show only the value of state labels
+func renderCardChips(task *Task, width int) string {
+	labels := taskLabels(task)
+	var chips []string
+	used := 0
+	for i, label := range labels {
+		text := sanitizeLine(label)
+		if _, value, ok := splitStateLabel(label); ok {
+			text = sanitizeLine(value)
+		}
+		need := lipgloss.Width(text)
+		if i > 0 {
+			need++ // Separating space
+		}
+		reserve := 0
+		if rest := len(labels) - i - 1; rest > 0 {
+			reserve = len(" +" + strconv.Itoa(rest))
+		}
+		if used+need+reserve > width {
+			more := "+" + strconv.Itoa(len(labels)-i)
+			if used+len(more)+1 <= width {
+				chips = append(chips, styleSubdued.Render(more))
+			}
+			break
+		}
+		chips = append(chips, lipgloss.NewStyle().Foreground(labelColor(label)).Render(text))
+		used += need
+	}
+	return strings.Join(chips, " ")
+}
+
+// renderLabelSection renders the detail panel's labels and state dimensions
+func renderLabelSection(task *Task, contentWidth int) string {
+	var plain []string
+	states := make(map[string][]string)
+	var dimensions []string
+	for _, label := range taskLabels(task) {
+		dimension, value, ok := splitStateLabel(label)
+		if !ok {
+			plain = append(plain, label)
+			continue
+		}
+		if _, seen := states[dimension]; !seen {
+			dimensions = append(dimensions, dimension)
+		}
+		states[dimension] = append(states[dimension], value)
+	}
+	sort.Strings(dimensions)
+
+	var content strings.Builder
+	if len(plain) > 0 {
+		content.WriteString(styleDetailLabel.Render("Labels:"))
+		content.WriteString("\n")
+		content.WriteString(lipgloss.NewStyle().Width(contentWidth).Render(renderChips(plain)))
+		content.WriteString("\n\n")
+	}
+	if len(dimensions) > 0 {
+		content.WriteString(styleDetailLabel.Render("State:"))
+		content.WriteString("\n")
+		for _, dimension := range dimensions {
+			content.WriteString("  " + styleSubdued.Render(sanitizeLine(dimension)+": "))
+			for _, value := range states[dimension] {
+				content.WriteString(styleLabel.Foreground(labelColor(dimension)).Render(sanitizeLine(value)))
+			}
+			content.WriteString("\n")
+		}
+		content.WriteString("\n")
+	}
+	return content.String()
+}
+
+// renderChips renders labels as chips
+func renderChips(labels []string) string {
+	var chips strings.Builder
+	for _, label := range labels {
+		chips.WriteString(renderLabelChip(label))
+	}
+	return chips.String()
+}
+
+// matchesLabelTerm reports whether a task has the label of a #term: the
+// label itself, or any value of a dimension given as #dimension:
+func matchesLabelTerm(task *Task, term string) bool {
+	term = strings.ToLower(term)
+	for _, label := range taskLabels(task) {
+		label = strings.ToLower(label)
+		if label == term || (strings.HasSuffix(term, ":") && strings.HasPrefix(label, term)) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesFilterText reports whether a task matches filter text: every #term
+// must be one of its labels, every resolution:<kind> its resolution kind, and
+// the rest must appear in its title, description, labels or resolution
+func matchesFilterText(task *Task, filter string) bool {
+	var text []string
+	for _, term := range strings.Fields(filter) {
+		if strings.HasPrefix(term, "#") && len(term) > 1 {
+			if !matchesLabelTerm(task, term[1:]) {
+				return false
+			}
+			continue
+		}
+		if kind, ok := strings.CutPrefix(term, "resolution:"); ok {
+			if task.Resolution == "" || (kind != "" && resolutionKey(task.Resolution) != strings.ToLower(kind)) {
+				return false
+			}
+			continue
+		}
+		text = append(text, term)
+	}
+	if len(text) == 0 {
+		return true
+	}
+
+	query := strings.ToLower(strings.Join(text, " "))
+	if strings.Contains(strings.ToLower(task.Title), query) || strings.Contains(strings.ToLower(task.Description), query) ||
+		strings.Contains(strings.ToLower(task.Resolution), query) {
+		return true
+	}
+	for _, label := range taskLabels(task) {
+		if strings.Contains(strings.ToLower(label), query) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,626 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// lanes.go - Swimlanes
+// In swimlane mode (L cycles the grouping) the board is split into horizontal
+// lanes by assignee, priority, first label or parent epic. Each lane shows the
+// columns side by side, lanes collapse with z, hjkl crosses lane boundaries,
+// and dropping a card into another lane changes the grouped attribute.
+// selectedColumn/selectedTask keep pointing into the column's task list, so
+// the rest of the board works unchanged; selectedTask is -1 when the
+// selected lane has no card in the selected column
+
+// LaneGrouping is the attribute cards are grouped into lanes by
+type LaneGrouping string
+
+const (
+	LaneNone     LaneGrouping = ""
+	LaneAssignee LaneGrouping = "assignee"
+	LanePriority LaneGrouping = "priority"
+	LaneLabel    LaneGrouping = "label"
+	LaneEpic     LaneGrouping = "epic"
+)
+
+// laneGroupings is the order L cycles through
+var laneGroupings = []LaneGrouping{LaneNone, LaneAssignee, LanePriority, LaneLabel, LaneEpic}
+
+// laneMaxCards is how many cards a lane shows per column before scrolling
+const laneMaxCards = 4
+
+// lane is one horizontal band of the board
+type lane struct {
+	key   string    // Attribute value the lane stands for ("" for none)
+	title string    // Shown in the lane header
+	cells [][]*Task // Cards per column, in column order
+	count int
+}
+
+// laneKey returns the value a task is grouped by
+func laneKey(task *Task, grouping LaneGrouping) string {
+	switch grouping {
+	case LaneAssignee:
+		return task.Assignee
+	case LanePriority:
+		return task.Priority.String()
+	case LaneLabel:
+		if labels := taskLabels(task); len(labels) > 0 {
+			return labels[0]
+		}
+	case LaneEpic:
+		return task.Parent
+	}
+	return ""
+}
+
+// lanes groups the board's cards into lanes for the current grouping
+func (m Model) lanes() []lane {
+	byKey := make(map[string]*lane)
+	var keys []string
+	add := func(key string) *lane {
+		if l, ok := byKey[key]; ok {
+			return l
+		}
+		l := &lane{key: key, title: m.laneTitle(key), cells: make([][]*Task, len(m.board.Columns))}
+		byKey[key] = l
+		keys = append(keys, key)
+		return l
+	}
+
+	// Every priority gets a lane so cards can be dropped into empty ones
+	if m.laneMode == LanePriority {
+		for _, p := range batchPriorities {
+			add(p.String())
+		}
+	}
+
+	for ci, col := range m.board.Columns {
+		for _, task := range col.Tasks {
+			l := add(laneKey(task, m.laneMode))
+			l.cells[ci] = append(l.cells[ci], task)
+			l.count++
+		}
+	}
+
+	// Priority lanes keep their order; others sort by title with "none" last
+	if m.laneMode != LanePriority {
+		sort.SliceStable(keys, func(i, j int) bool {
+			if (keys[i] == "") != (keys[j] == "") {
+				return keys[j] == ""
+			}
+			return strings.ToLower(byKey[keys[i]].title) < strings.ToLower(byKey[keys[j]].title)
+		})
+	}
+
+	result := make([]lane, len(keys))
+	for i, key := range keys {
+		result[i] = *byKey[key]
+	}
+	return result
+}
+
+// laneTitle returns the header text for a lane key
+func (m Model) laneTitle(key string) string {
+	switch m.laneMode {
+	case LaneAssignee:
+		if key == "" {
+			return "Unassigned"
+		}
+	case LanePriority:
+		return strings.ToUpper(key[:1]) + key[1:]
+	case LaneLabel:
+		if key == "" {
+			return "No label"
+		}
+	case LaneEpic:
+		if key == "" {
+			return "No epic"
+		}
+		for _, task := range m.board.Tasks {
+			if task.ID == key {
+				return sanitizeLine(task.Title)
+			}
+		}
+	}
+	return sanitizeLine(key)
+}
+
+// cycleLaneMode switches to the next lane grouping
+func (m *Model) cycleLaneMode() {
+	for i, g := range laneGroupings {
+		if g == m.laneMode {
+			m.laneMode = laneGroupings[(i+1)%len(laneGroupings)]
+			break
+		}
+	}
+
+	if m.laneMode == LaneNone {
+		m.statusMessage = "Swimlanes off"
+		if m.selectedTask < 0 {
+			m.selectedTask = 0
+		}
+		m.updateScrollOffset()
+		return
+	}
+	m.statusMessage = "Swimlanes by " + string(m.laneMode)
+	m.syncLaneToTask()
+	if m.getCurrentTask() == nil {
+		m.selectLaneCell(m.selectedLane, 0)
+	}
+}
+
+// currentLaneIndex returns the lane of the selected card, or the selected
+// lane when no card is selected (an empty or collapsed cell)
+func (m Model) currentLaneIndex() int {
+	lanes := m.lanes()
+	if task := m.getCurrentTask(); task != nil {
+		key := laneKey(task, m.laneMode)
+		for i, l := range lanes {
+			if l.key == key {
+				return i
+			}
+		}
+	}
+	if m.selectedLane >= len(lanes) {
+		return len(lanes) - 1
+	}
+	return m.selectedLane
+}
+
+// syncLaneToTask makes selectedLane follow the selected card
+func (m *Model) syncLaneToTask() {
+	m.selectedLane = m.currentLaneIndex()
+	if m.selectedLane < 0 {
+		m.selectedLane = 0
+	}
+}
+
+// laneID identifies a lane across groupings for the collapsed set
+func (m Model) laneID(key string) string {
+	return string(m.laneMode) + ":" + key
+}
+
+// isLaneCollapsed reports whether a lane only shows its header
+func (m Model) isLaneCollapsed(key string) bool {
+	return m.collapsedLanes[m.laneID(key)]
+}
+
+// toggleLaneCollapsed collapses or expands the selected lane
+func (m *Model) toggleLaneCollapsed() {
+	li := m.currentLaneIndex()
+	lanes := m.lanes()
+	if li < 0 || li >= len(lanes) {
+		return
+	}
+	if m.collapsedLanes == nil {
+		m.collapsedLanes = make(map[string]bool)
+	}
+	id := m.laneID(lanes[li].key)
+	m.collapsedLanes[id] = !m.collapsedLanes[id]
+	m.selectLaneCell(li, 0)
+}
+
+// laneCellAt returns a lane's cards in a column (none if collapsed)
+func (m Model) laneCellAt(laneIndex, colIndex int) []*Task {
+	lanes := m.lanes()
+	if laneIndex < 0 || laneIndex >= len(lanes) || colIndex < 0 || colIndex >= len(m.board.Columns) {
+		return nil
+	}
+	if m.isLaneCollapsed(lanes[laneIndex].key) {
+		return nil
+	}
+	return lanes[laneIndex].cells[colIndex]
+}
+
+// lanePosition returns the selected card's position in its lane cell (-1 if none)
+func (m Model) lanePosition() int {
+	task := m.getCurrentTask()
+	for i, t := range m.laneCellAt(m.currentLaneIndex(), m.selectedColumn) {
+		if t == task {
+			return i
+		}
+	}
+	return -1
+}
+
+// selectLaneCell selects a lane and the card at pos in its cell of the
+// selected column (clamped), or no card if the cell is empty or collapsed
+func (m *Model) selectLaneCell(laneIndex, pos int) {
+	cell := m.laneCellAt(laneIndex, m.selectedColumn)
+	m.selectedLane = laneIndex
+	m.selectedTask = -1
+	if len(cell) == 0 {
+		return
+	}
+	if pos >= len(cell) {
+		pos = len(cell) - 1
+	}
+	if pos < 0 {
+		pos = 0
+	}
+	for i, t := range m.board.Columns[m.selectedColumn].Tasks {
+		if t == cell[pos] {
+			m.selectedTask = i
+			return
+		}
+	}
+}
+
+// moveLaneSelectionHorizontal moves to the neighbouring column in the same lane
+func (m *Model) moveLaneSelectionHorizontal(delta int) {
+	target := m.selectedColumn + delta
+	if target < 0 || target >= len(m.board.Columns) {
+		return
+	}
+	li, pos := m.currentLaneIndex(), m.lanePosition()
+	m.selectedColumn = target
+	m.selectLaneCell(li, pos)
+	m.ensureSelectedColumnVisible()
+}
+
+// moveLaneSelectionVertical moves within the lane cell, crossing into the
+// lane above or below at the cell's edge
+func (m *Model) moveLaneSelectionVertical(delta int) {
+	li, pos := m.currentLaneIndex(), m.lanePosition()
+	cell := m.laneCellAt(li, m.selectedColumn)
+	if next := pos + delta; pos >= 0 && next >= 0 && next < len(cell) {
+		m.selectLaneCell(li, next)
+		return
+	}
+
+	target := li + delta
+	if target < 0 || target >= len(m.lanes()) {
+		return
+	}
+	if delta > 0 {
+		m.selectLaneCell(target, 0)
+	} else {
+		m.selectLaneCell(target, len(m.laneCellAt(target, m.selectedColumn))-1)
+	}
+}
+
+// cellWindow returns which cards of a cell are shown: cards from start to
+// end, keeping the selected card (at pos, or -1) in view
+func cellWindow(count, pos int) (start, end int) {
+	end = count
+	if count > laneMaxCards {
+		end = laneMaxCards
+		if pos >= laneMaxCards {
+			end = pos + 1
+		}
+	}
+	start = end - laneMaxCards
+	if start < 0 {
+		start = 0
+	}
+	return start, end
+}
+
+// cellHeight returns the lines a lane cell needs for a window of cards
+func cellHeight(count, start, end int) int {
+	if count == 0 {
+		return 1
+	}
+	height := 2*(end-start-1) + cardHeight
+	if start > 0 {
+		height++ // "↑ N more"
+	}
+	if end < count {
+		height++ // "↓ N more"
+	}
+	return height
+}
+
+// laneRow is a lane's place in the rendered board
+type laneRow struct {
+	index     int // Index into lanes()
+	y         int // First line (the header), relative to the task area
+	height    int // Header plus body
+	collapsed bool
+	windows   [][2]int // Per column: shown card range
+}
+
+// laneRows lays out the lanes that fit in the task area, scrolled so the
+// selected lane is visible. Shared by rendering and mouse hit-testing
+func (m Model) laneRows(lanes []lane, contentHeight int) []laneRow {
+	selectedTask := m.getCurrentTask()
+	selectedLane := m.currentLaneIndex()
+
+	rows := make([]laneRow, len(lanes))
+	for i, l := range lanes {
+		row := laneRow{index: i, height: 1, collapsed: m.isLaneCollapsed(l.key)}
+		if !row.collapsed {
+			body := 1
+			row.windows = make([][2]int, len(l.cells))
+			for ci, cell := range l.cells {
+				pos := -1
+				if i == selectedLane && ci == m.selectedColumn {
+					for p, t := range cell {
+						if t == selectedTask {
+							pos = p
+						}
+					}
+				}
+				start, end := cellWindow(len(cell), pos)
+				row.windows[ci] = [2]int{start, end}
+				if h := cellHeight(len(cell), start, end); h > body {
+					body = h
+				}
+			}
+			row.height += body
+		}
+		rows[i] = row
+	}
+
+	// Scroll down until the selected lane fits
+	first := 0
+	for first < selectedLane && first < len(rows) {
+		used := 0
+		for i := first; i <= selectedLane && i < len(rows); i++ {
+			used += rows[i].height
+		}
+		if used <= contentHeight {
+			break
+		}
+		first++
+	}
+
+	var visible []laneRow
+	y := 0
+	for i := first; i < len(rows) && y < contentHeight; i++ {
+		rows[i].y = y
+		visible = append(visible, rows[i])
+		y += rows[i].height
+	}
+	return visible
+}
+
+// renderLanes renders the board as swimlanes below the column headers
+func (m Model) renderLanes(contentHeight int) string {
+	startCol, endCol, visibleCount := m.getVisibleColumnRange()
+	colWidth := m.boardWidth / visibleCount
+	indent := 0
+	if m.narrowMode && m.visibleColumnStart > 0 {
+		indent = 5 // Left scroll indicator
+		colWidth = (m.boardWidth - 10) / visibleCount
+	}
+
+	lanes := m.lanes()
+	selectedLane := m.currentLaneIndex()
+	var blocks []string
+	for _, row := range m.laneRows(lanes, contentHeight) {
+		l := lanes[row.index]
+
+		// Header: fold marker, title and card count
+		marker := "▾"
+		if row.collapsed {
+			marker = "▸"
+		}
+		header := fmt.Sprintf("%s %s (%d)", marker, truncateText(l.title, 40), l.count)
+		style := lipgloss.NewStyle().Foreground(colorSecondary).Bold(true)
+		if row.index == selectedLane {
+			style = lipgloss.NewStyle().Foreground(colorSelected).Bold(true)
+		}
+		if m.draggingTask != nil && m.dropTargetLane == row.index {
+			header += " ←"
+		}
+		blocks = append(blocks, style.Width(m.boardWidth).Render(header))
+		if row.collapsed {
+			continue
+		}
+
+		var cells []string
+		if indent > 0 {
+			cells = append(cells, strings.Repeat(" ", indent))
+		}
+		for ci := startCol; ci < endCol; ci++ {
+			cells = append(cells, m.renderLaneCell(l.cells[ci], row.index, row.index == selectedLane, ci, row.windows[ci], row.height-1, colWidth))
+		}
+		blocks = append(blocks, lipgloss.JoinHorizontal(lipgloss.Top, cells...))
+	}
+
+	if len(lanes) == 0 {
+		blocks = append(blocks, styleSubdued.Render("No cards"))
+	}
+
+	return lipgloss.NewStyle().
+		Width(m.boardWidth).
+		Height(contentHeight).
+		MaxHeight(contentHeight).
+		Render(lipgloss.JoinVertical(lipgloss.Left, blocks...))
+}
+
+// renderLaneCell renders one column of a lane, stacked like a column
+func (m Model) renderLaneCell(cell []*Task, laneIndex int, laneSelected bool, colIndex int, window [2]int, height, colWidth int) string {
+	var content strings.Builder
+	start, end := window[0], window[1]
+	selected := m.getCurrentTask()
+
+	if start > 0 {
+		content.WriteString(styleSubdued.Render(fmt.Sprintf("↑ %d more", start)) + "\n")
+	}
+
+	for i := start; i < end; i++ {
+		task := cell[i]
+		isSelected := colIndex == m.selectedColumn && task == selected
+		isDragging := task == m.draggingTask || (m.draggingTask != nil && m.isMarked(m.draggingTask) && m.isMarked(task))
+		ghost := isDragging || !m.taskMatchesFilter(task)
+		progress := m.epicProgress(task)
+
+		switch {
+		case i == end-1 && ghost:
+			content.WriteString(renderCardGhost(task, progress))
+		case i == end-1:
+			content.WriteString(renderCard(task, isSelected, m.isMarked(task), progress))
+		case ghost:
+			content.WriteString(renderCardTopLinesGhost(task, progress) + "\n")
+		default:
+			content.WriteString(renderCardTopLines(task, isSelected, m.isMarked(task), progress) + "\n")
+		}
+	}
+
+	if end < len(cell) {
+		content.WriteString("\n" + styleSubdued.Render(fmt.Sprintf("↓ %d more", len(cell)-end)))
+	}
+
+	// Drop indicator at the end of the target cell
+	if m.draggingTask != nil && m.dropTargetLane == laneIndex && m.dropTargetColumn == colIndex {
+		if len(cell) > 0 {
+			content.WriteString("\n")
+		}
+		content.WriteString(styleDropIndicator.Render(strings.Repeat("-", cardWidth)))
+	}
+
+	// An empty cell in the selected lane shows where the selection is
+	if len(cell) == 0 && laneSelected && colIndex == m.selectedColumn && m.draggingTask == nil {
+		content.WriteString(styleSubdued.Render("·"))
+	}
+
+	return lipgloss.NewStyle().
+		Width(colWidth).
+		Height(height).
+		Align(lipgloss.Center).
+		Render(content.String())
+}
+
+// laneAt returns the lane row and the line within it at a task-area Y position
+func (m Model) laneAt(relY int) (laneRow, int, bool) {
+	for _, row := range m.laneRows(m.lanes(), m.getContentHeight()) {
+		if relY >= row.y && relY < row.y+row.height {
+			return row, relY - row.y, true
+		}
+	}
+	return laneRow{}, 0, false
+}
+
+// laneTaskAt returns the card under a line of a lane cell's body, or nil
+func laneTaskAt(cell []*Task, window [2]int, line int) *Task {
+	start, end := window[0], window[1]
+	if start >= end {
+		return nil
+	}
+	if start > 0 {
+		line-- // "↑ N more"
+	}
+	if line < 0 {
+		return cell[start]
+	}
+	for i := start; i < end-1; i++ {
+		if line < (i-start+1)*2 {
+			return cell[i]
+		}
+	}
+	if line < 2*(end-start-1)+cardHeight {
+		return cell[end-1]
+	}
+	return nil
+}
+
+// selectLaneTask selects a card (or just the lane cell if task is nil)
+func (m *Model) selectLaneTask(laneIndex, colIndex int, task *Task) {
+	m.selectedLane = laneIndex
+	m.selectedColumn = colIndex
+	m.selectedTask = -1
+	if task == nil {
+		return
+	}
+	for i, t := range m.board.Columns[colIndex].Tasks {
+		if t == task {
+			m.selectedTask = i
+		}
+	}
+}
+
+// setLaneAttribute gives a task the attribute of a lane; it reports false
+// when the lane can't be assigned (dropping into "No label")
+func (m *Model) setLaneAttribute(task *Task, l lane) bool {
+	if laneKey(task, m.laneMode) == l.key {
+		return true
+	}
+
+	switch m.laneMode {
+	case LaneAssignee:
+		task.Assignee = l.key
+	case LanePriority:
+		for _, p := range batchPriorities {
+			if p.String() == l.key {
+				task.Priority = p
+			}
+		}
+	case LaneLabel:
+		if l.key == "" {
+			return false // Dropping shouldn't strip labels
+		}
+		// The lane's label replaces the card's first one
+		labels := []string{l.key}
+		for i, label := range taskLabels(task) {
+			if i > 0 && label != l.key {
+				labels = append(labels, label)
+			}
+		}
+		setTaskLabels(task, labels)
+	case LaneEpic:
+		task.Parent = l.key
+	}
+
+	if m.backend != nil {
+		if err := m.backend.UpdateTask(task); err != nil {
+			m.statusMessage = err.Error()
+		}
+	}
+	return true
+}
+
+// dropIntoLane moves the dragged card (or the whole selection, when a marked
+// card is dragged) into a lane cell, changing column and lane attribute
+func (m *Model) dropIntoLane(laneIndex, toColIndex int) {
+	lanes := m.lanes()
+	if laneIndex < 0 || laneIndex >= len(lanes) {
+		return
+	}
+	l := lanes[laneIndex]
+	col := m.board.Columns[toColIndex]
+
+	tasks := []*Task{m.draggingTask}
+	if m.isMarked(m.draggingTask) {
+		tasks = m.markedTasks()
+	}
+
+	refused := 0
+	for _, task := range tasks {
+		if !m.setLaneAttribute(task, l) {
+			refused++
+		}
+	}
+	if m.promptResolution(tasks, col.ID) {
+		return
+	}
+	failed, err := m.moveTasksTo(tasks, col.ID)
+
+	switch {
+	case refused > 0:
+		m.statusMessage = "Moved to " + col.Title + "; drop into a labelled lane to relabel"
+	case len(tasks) > 1:
+		m.statusMessage = fmt.Sprintf("Moved %d cards to %s / %s", len(tasks)-failed, col.Title, l.title)
+	}
+	if failed > 0 {
+		m.statusMessage += fmt.Sprintf(", %d failed", failed)
+	}
+	m.statusMessage += saveFailure(err)
+
+	// Follow the dragged card to its new lane
+	m.selectedColumn = toColIndex
+	m.selectedTask = -1
+	for i, t := range m.board.Columns[toColIndex].Tasks {
+		if t == m.draggingTask {
+			m.selectedTask = i
+		}
+	}
+	m.syncLaneToTask()
+}
@@ -0,0 +1,310 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// launcher.go - Pluggable terminal launchers for agent chats
+// A Launcher opens an interactive agent chat for a task somewhere the user can
+// see it: a tmux popup or window, a zellij floating pane, this terminal
+// (suspending the TUI), or any terminal via a custom command template
+
+// LaunchSpec describes a chat to open
+type LaunchSpec struct {
+	TaskID string
+	Title  string   // Window/pane title
+	Dir    string   // Working directory (empty for the launcher's default)
+	Argv   []string // Agent command and arguments, run without a shell
+
+	NewSession string // Session recorded for this chat, dropped if the chat can't start
+}
+
+// Launcher opens a chat command in a terminal
+type Launcher interface {
+	// Name returns the launcher's --launcher name
+	Name() string
+	// Launch returns a command that opens the chat and reports a chatFinishedMsg
+	Launch(spec LaunchSpec) tea.Cmd
+}
+
+// chatFinishedMsg is sent when a chat launch returns (or fails to start)
+type chatFinishedMsg struct {
+	taskID     string
+	newSession string // LaunchSpec.NewSession
+	err        error
+}
+
+// Launcher names accepted by --launcher
+const (
+	LauncherTmuxPopup  = "tmux-popup"
+	LauncherTmuxWindow = "tmux-window"
+	LauncherZellij     = "zellij"
+	LauncherExec       = "exec"
+	LauncherCustom     = "custom"
+)
+
+// launcherNames lists the launchers in the order shown by --help
+var launcherNames = []string{LauncherTmuxPopup, LauncherTmuxWindow, LauncherZellij, LauncherExec, LauncherCustom}
+
+// newLauncher creates a launcher by name; "" or "auto" picks one for the
+// current environment. template is used by the custom launcher and
+// popupSize ("80%" or "120x40") by the tmux popup
+func newLauncher(name, template, popupSize string) (Launcher, error) {
+	switch name {
+	case "", "auto":
+		return detectLauncher(popupSize), nil
+	case LauncherTmuxPopup:
+		width, height := parsePopupSize(popupSize)
+		return tmuxPopupLauncher{width: width, height: height}, nil
+	case LauncherTmuxWindow:
+		return tmuxWindowLauncher{}, nil
+	case LauncherZellij:
+		return zellijLauncher{}, nil
+	case LauncherExec:
+		return execLauncher{}, nil
+	case LauncherCustom:
+		if strings.TrimSpace(template) == "" {
+			return nil, fmt.Errorf("the custom launcher needs --launcher-cmd")
+		}
+		return customLauncher{template: template}, nil
+	}
+	return nil, fmt.Errorf("unknown launcher %q (want one of %s)", name, strings.Join(launcherNames, ", "))
+}
+
+// detectLauncher picks tmux or zellij when running inside them, else exec
+func detectLauncher(popupSize string) Launcher {
+	switch {
+	case os.Getenv("TMUX") != "":
+		width, height := parsePopupSize(popupSize)
+		return tmuxPopupLauncher{width: width, height: height}
+	case os.Getenv("ZELLIJ") != "":
+		return zellijLauncher{}
+	}
+	return execLauncher{}
+}
+
+// runExternal runs a launcher command in the background and reports how it went
+// Output is captured so tmux/zellij errors can be shown in the status bar; on
+// failure the unused launch spec file (if any) is removed
+func runExternal(spec LaunchSpec, cmd *exec.Cmd, specFile string) tea.Cmd {
+	return func() tea.Msg {
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			if specFile != "" {
+				os.Remove(specFile)
+			}
+			if msg := strings.TrimSpace(string(out)); msg != "" {
+				err = fmt.Errorf("%s: %s", cmd.Args[0], msg)
+			}
+		}
+		return chatFinishedMsg{taskID: spec.TaskID, newSession: spec.NewSession, err: err}
+	}
+}
+
+// launchError returns a command reporting a launch that couldn't start
+func launchError(spec LaunchSpec, err error) tea.Cmd {
+	return func() tea.Msg {
+		return chatFinishedMsg{taskID: spec.TaskID, newSession: spec.NewSession, err: err}
+	}
+}
+
+// zellijLauncher opens the chat in a floating zellij pane
+type zellijLauncher struct{}
+
+func (zellijLauncher) Name() string { return LauncherZellij }
+
+func (zellijLauncher) Launch(spec LaunchSpec) tea.Cmd {
+	if os.Getenv("ZELLIJ") == "" {
+		return launchError(spec, fmt.Errorf("not running inside zellij"))
+	}
+
+	argv, specFile, err := handoffArgv(spec)
+	if err != nil {
+		return launchError(spec, err)
+	}
+
+	args := []string{"run", "--floating", "--close-on-exit", "--name", spec.Title}
+	if spec.Dir != "" {
+		args = append(args, "--cwd", spec.Dir)
+	}
+	args = append(args, "--")
+	args = append(args, argv...)
+	return runExternal(spec, exec.Command("zellij", args...), specFile)
+}
+
+// execLauncher suspends the TUI and runs the chat in this terminal
+type execLauncher struct{}
+
+func (execLauncher) Name() string { return LauncherExec }
+
+func (execLauncher) Launch(spec LaunchSpec) tea.Cmd {
+	cmd := exec.Command(spec.Argv[0], spec.Argv[1:]...)
+	cmd.Dir = spec.Dir
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return chatFinishedMsg{taskID: spec.TaskID, newSession: spec.NewSession, err: err}
+	})
+}
+
+// customLauncher runs a user-supplied command template, e.g.
+// "wezterm cli spawn --cwd {dir} -- {cmd}"
+// The template is split on whitespace (no shell); {cmd} expands to a
+// `launch-spec` command for the chat, {dir} and {title} are substituted within words
+type customLauncher struct {
+	template string
+}
+
+func (customLauncher) Name() string { return LauncherCustom }
+
+func (l customLauncher) Launch(spec LaunchSpec) tea.Cmd {
+	dir := spec.Dir
+	if dir == "" {
+		dir, _ = os.Getwd()
+	}
+
+	argv, specFile, err := handoffArgv(spec)
+	if err != nil {
+		return launchError(spec, err)
+	}
+
+	var args []string
+	hasCmd := false
+	for _, word := range strings.Fields(l.template) {
+		if word == "{cmd}" {
+			args = append(args, argv...)
+			hasCmd = true
+			continue
+		}
+		word = strings.ReplaceAll(word, "{dir}", dir)
+		word = strings.ReplaceAll(word, "{title}", spec.Title)
+		args = append(args, word)
+	}
+	if !hasCmd {
+		args = append(args, argv...)
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Dir = spec.Dir
+	return runExternal(spec, cmd, specFile)
+}
+
+// chatCommand returns the interactive command for chatting with an agent,
+// starting with prompt as the first message. With a session, Claude either
+// starts it under that ID or resumes it (the prompt is not repeated)
+func chatCommand(agentType AgentType, prompt string, session *ChatSession, resume bool) []string {
+	switch agentType {
+	case AgentCodex:
+		return []string{"codex", prompt}
+	case AgentGeminiCLI:
+		return []string{"gemini", "-i", prompt}
+	}
+
+	if session != nil && resume {
+		return []string{"claude", "--resume", session.ID}
+	}
+	if session != nil {
+		return []string{"claude", "--session-id", session.ID, prompt}
+	}
+	return []string{"claude", prompt}
+}
+
+// startChat opens a chat about the selected task with the configured launcher
+// The column's agent is used (Claude by default) and the prompt ends with the
+// column's step prompt. Unless fresh is set, the task's last session with that
+// agent is resumed
+func (m *Model) startChat(fresh bool) tea.Cmd {
+	task := m.getCurrentTask()
+	if task == nil {
+		return nil
+	}
+	col := m.getCurrentColumn()
+	agentType := chatAgentType(col)
+
+	dir := ""
+	if task.Git != nil {
+		dir = task.Git.Worktree
+	}
+
+	if m.launcher == nil {
+		m.launcher = detectLauncher("")
+	}
+
+	// Pick up the previous conversation, or start a new recorded one
+	var session *ChatSession
+	resume := false
+	newSession := ""
+	if supportsSessionID(agentType) {
+		if !fresh {
+			session = latestSession(task, agentType)
+		}
+		if session != nil && liveRunSession(task, session.ID) {
+			m.statusMessage = fmt.Sprintf("The agent run on %s is still going; start a new chat instead", task.ID)
+			return nil
+		}
+		if session != nil {
+			resume = true
+			session.LastUsed = time.Now()
+		} else {
+			columnTitle := ""
+			if col != nil {
+				columnTitle = col.Title
+			}
+			session = recordSession(task, agentType, sessionKindChat, columnTitle)
+			newSession = session.ID
+		}
+		if m.backend != nil {
+			if err := m.backend.UpdateTask(task); err != nil {
+				m.statusMessage = "Session not saved: " + err.Error()
+			}
+		}
+	}
+
+	prompt := buildTaskPrompt(task, col, m.fetchIssueDetails(), m.board.Context)
+	return m.launcher.Launch(LaunchSpec{
+		TaskID: task.ID,
+		Title:  truncateText(sanitizeLine(task.Title), 40),
+		Dir:    dir,
+		Argv:   chatCommand(agentType, prompt, session, resume),
+
+		NewSession: newSession,
+	})
+}
+
+// chatAgentType returns the agent chats use in a column (Claude unless the
+// column assigns one)
+func chatAgentType(col *Column) AgentType {
+	if col != nil && col.AssignedAgent != "" {
+		return col.AssignedAgent
+	}
+	return AgentClaudeCode
+}
+
+// handleChatFinished reports launch failures in the status bar. A session
+// recorded for a chat that never started is removed again, so the next chat
+// doesn't try to resume it
+func (m *Model) handleChatFinished(msg chatFinishedMsg) {
+	if msg.err == nil {
+		return
+	}
+	m.statusMessage = fmt.Sprintf("Chat (%s) failed: %v", m.launcher.Name(), msg.err)
+
+	var exitErr *exec.ExitError
+	if msg.newSession == "" || errors.As(msg.err, &exitErr) {
+		return // The chat ran and exited with an error, its session exists
+	}
+	task := findTaskByID(m.board, msg.taskID)
+	if task == nil || !removeSession(task, msg.newSession) {
+		return
+	}
+	if m.backend != nil {
+		if err := m.backend.UpdateTask(task); err != nil {
+			m.statusMessage += "; " + err.Error()
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -20,16 +21,93 @@ func beadsProjectDetected() bool {
 	return err == nil && info.IsDir()
 }
 
+// backendFlags holds the flags shared by every mode that opens a board
+type backendFlags struct {
+	boardFile  *string
+	beadsMode  *bool
+	noBeads    *bool
+	beadsJSONL *bool
+}
+
+// registerBackendFlags adds the backend selection flags to a flag set
+func registerBackendFlags(fs *flag.FlagSet) backendFlags {
+	return backendFlags{
+		boardFile:  fs.String("board", "board.yaml", "Path to board YAML/JSON file"),
+		beadsMode:  fs.Bool("beads", false, "Use beads issue tracker as backend"),
+		noBeads:    fs.Bool("no-beads", false, "Force local YAML backend (disable auto-detect)"),
+		beadsJSONL: fs.Bool("beads-jsonl", false, "Read beads issues from "+beadsIssuesFile+" instead of bd list (faster; bd is still used for changes)"),
+	}
+}
+
+// useBeads reports whether the beads backend should be used
+// Explicit flag > auto-detect > local
+func (f backendFlags) useBeads() bool {
+	return *f.beadsMode || (!*f.noBeads && beadsProjectDetected())
+}
+
+// openBackend creates the backend selected by the flags
+func (f backendFlags) openBackend() Backend {
+	if f.useBeads() {
+		backend := NewBeadsBackend()
+		backend.jsonlOnly = *f.beadsJSONL
+		return backend
+	}
+	return NewLocalBackend(*f.boardFile)
+}
+
 func main() {
+	// Subcommands are dispatched before the TUI flags are parsed
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "serve":
+			runServe(os.Args[2:])
+			return
+		case "mcp":
+			runMCP(os.Args[2:])
+			return
+		case "init":
+			runInit(os.Args[2:])
+			return
+		case "add":
+			runAdd(os.Args[2:])
+			return
+		case "migrate":
+			runMigrate(os.Args[2:])
+			return
+		case "export":
+			runExport(os.Args[2:])
+			return
+		case "import":
+			runImport(os.Args[2:])
+			return
+		case "launch-spec":
+			// Internal: used by chat launchers, see handoff.go
+			runLaunchSpec(os.Args[2:])
+			return
+		}
+	}
+
 	// Parse command-line flags
-	var (
-		boardFile = flag.String("board", "board.yaml", "Path to board YAML/JSON file")
-		beadsMode = flag.Bool("beads", false, "Use beads issue tracker as backend")
-		noBeads   = flag.Bool("no-beads", false, "Force local YAML backend (disable auto-detect)")
-		help      = flag.Bool("help", false, "Show help")
-	)
+	backendOpts := registerBackendFlags(flag.CommandLine)
+	boardsDir := flag.String("boards-dir", "", "Directory scanned for boards by the switcher (default: the board file's directory)")
+	rulesDryRun := flag.Bool("rules-dry-run", false, "Show what auto-advance rules would do without moving cards")
+	launcherName := flag.String("launcher", "auto", "Where chats open: auto, "+strings.Join(launcherNames, ", "))
+	launcherCmd := flag.String("launcher-cmd", "", "Command template for --launcher=custom, e.g. \"wezterm cli spawn --cwd {dir} -- {cmd}\"")
+	popupSize := flag.String("popup-size", defaultPopupSize, "tmux popup size: 80% or WxH (e.g. 120x40, 90%x70%)")
+	themeName := flag.String("theme", "", "Color theme: dark, light, high-contrast or a theme in the config dir (default: config's theme or dark)")
+	help := flag.Bool("help", false, "Show help")
 	flag.Parse()
 
+	// Theme and key bindings from the user config (applied first so --help shows them)
+	userConfig, err := loadUserConfig()
+	if err == nil {
+		err = applyUserConfig(userConfig, *themeName)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
 	if *help {
 		fmt.Println("AI Kanban Board TUI")
 		fmt.Println()
@@ -38,31 +116,40 @@ func main() {
 		fmt.Println("  ai-kanban-tui --board=tasks.yaml # Use custom board file")
 		fmt.Println("  ai-kanban-tui --beads            # Force beads backend")
 		fmt.Println("  ai-kanban-tui --no-beads         # Force local YAML backend")
+		fmt.Println("  ai-kanban-tui --beads-jsonl      # Read .beads/issues.jsonl directly (used anyway when bd is missing)")
+		fmt.Println("  ai-kanban-tui --boards-dir=~/boards # Directory listed by the board switcher")
+		fmt.Println("  ai-kanban-tui --rules-dry-run    # Report auto-advance moves without making them")
+		fmt.Println("  ai-kanban-tui --launcher=zellij  # Open chats in tmux-popup, tmux-window, zellij, exec or custom")
+		fmt.Println("  ai-kanban-tui --theme=light      # Color theme (dark, light, high-contrast)")
+		fmt.Println()
+		fmt.Println("Commands:")
+		fmt.Println("  ai-kanban-tui init --template=feature-dev    # Create board.yaml from a template (--list to see all)")
+		fmt.Println("  ai-kanban-tui add Fix login !p1 #bug due:fri # Add a task using quick-capture tokens (add --help)")
+		fmt.Println("  ai-kanban-tui migrate --to=beads [--apply]   # Copy board.yaml into beads (--to=yaml for the reverse)")
+		fmt.Println("  ai-kanban-tui export --format=md [-o file]   # Export as md, csv, html or ics (--filter to narrow)")
+		fmt.Println("  ai-kanban-tui import [--dry-run] FILE        # Import todo.txt, task export or gh issue list JSON")
+		fmt.Println("  ai-kanban-tui serve [--addr=127.0.0.1:4243]  # Serve the board over HTTP/JSON")
+		fmt.Println("  ai-kanban-tui mcp [--agent=claude-code]      # MCP stdio server for coding agents")
 		fmt.Println()
 		fmt.Println("Keyboard shortcuts:")
-		fmt.Println("  arrows / hjkl  Navigate columns and tasks")
-		fmt.Println("  Enter / e      Open/edit selected task")
-		fmt.Println("  n              Create new task")
-		fmt.Println("  d              Delete selected task")
-		fmt.Println("  m / M          Move task to next/prev column")
-		fmt.Println("  c              Chat with Claude (tmux popup)")
-		fmt.Println("  B              Toggle beads/local backend")
-		fmt.Println("  /              Filter tasks")
-		fmt.Println("  Esc            Clear filter")
-		fmt.Println("  Tab            Toggle detail panel")
-		fmt.Println("  ?              Show help")
-		fmt.Println("  q              Quit")
+		fmt.Print(commandHelp(18))
+		if path, err := userConfigPath(); err == nil {
+			fmt.Println()
+			fmt.Printf("Keys and theme can be changed in %s\n", path)
+		}
 		os.Exit(0)
 	}
 
+	launcher, err := newLauncher(*launcherName, *launcherCmd, *popupSize)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
 	// Determine backend: explicit flag > auto-detect > local
-	var backend Backend
-	useBeads := *beadsMode || (!*noBeads && beadsProjectDetected())
-	if useBeads {
-		backend = NewBeadsBackend()
+	backend := backendOpts.openBackend()
+	if backendOpts.useBeads() {
 		fmt.Println("Using beads backend (detected .beads/ directory)")
-	} else {
-		backend = NewLocalBackend(*boardFile)
 	}
 
 	board, err := backend.LoadBoard()
@@ -73,6 +160,18 @@ func main() {
 
 	// Initialize model with backend
 	m := NewModelWithBackend(board, backend)
+	m.boardFile = *backendOpts.boardFile
+	m.boardsDir = *boardsDir
+	m.beadsJSONL = *backendOpts.beadsJSONL
+	m.rulesDryRun = *rulesDryRun
+	m.launcher = launcher
+
+	// Offer a template when starting without a board file
+	if _, isLocal := backend.(*LocalBackend); isLocal {
+		if _, err := os.Stat(*backendOpts.boardFile); os.IsNotExist(err) {
+			m.openTemplatePicker()
+		}
+	}
 
 	// Create Bubbletea program
 	p := tea.NewProgram(
@@ -0,0 +1,589 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// mcp.go - MCP (Model Context Protocol) stdio server
+// Lets coding agents read and update their own cards through structured
+// tool calls instead of shelling out to bd or editing board.yaml.
+//
+// Note: with the beads backend, agent status and logs are kept in
+// .beads/kanban-tasks.yaml since beads has no fields for them.
+
+// mcpProtocolVersion is the MCP revision this server implements
+const mcpProtocolVersion = "2024-11-05"
+
+// JSON-RPC error codes used by the MCP server
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+)
+
+// rpcRequest is an incoming JSON-RPC 2.0 request or notification
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"` // Absent for notifications
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// rpcResponse is an outgoing JSON-RPC 2.0 response
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcError is a JSON-RPC 2.0 error object
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// mcpTool describes a tool exposed to MCP clients
+type mcpTool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"inputSchema"`
+
+	handler func(args json.RawMessage) (any, error)
+}
+
+// mcpContent is a single content block in a tool result
+type mcpContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// mcpToolResult is the result of a tools/call request
+type mcpToolResult struct {
+	Content []mcpContent `json:"content"`
+	IsError bool         `json:"isError,omitempty"`
+}
+
+// MCPServer serves board operations to an MCP client over stdio
+type MCPServer struct {
+	backend      Backend
+	defaultAgent AgentType // Agent type recorded by claim_task when none is given
+	tools        []mcpTool
+}
+
+// NewMCPServer creates a new MCP server for the given backend
+func NewMCPServer(backend Backend, defaultAgent AgentType) *MCPServer {
+	s := &MCPServer{backend: backend, defaultAgent: defaultAgent}
+	s.tools = s.registerTools()
+	return s
+}
+
+// Serve reads newline-delimited JSON-RPC messages from r and writes responses to w
+// until r is exhausted
+func (s *MCPServer) Serve(r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	encoder := json.NewEncoder(w)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		resp := s.handleMessage([]byte(line))
+		if resp == nil {
+			continue // Notification, no response
+		}
+		if err := encoder.Encode(resp); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+// handleMessage dispatches a single JSON-RPC message
+// Returns nil for notifications, which must not be answered
+func (s *MCPServer) handleMessage(data []byte) *rpcResponse {
+	var req rpcRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return &rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"),
+			Error: &rpcError{Code: rpcParseError, Message: err.Error()}}
+	}
+
+	isNotification := len(req.ID) == 0
+	result, rpcErr := s.dispatch(req)
+	if isNotification {
+		return nil
+	}
+
+	resp := &rpcResponse{JSONRPC: "2.0", ID: req.ID}
+	if rpcErr != nil {
+		resp.Error = rpcErr
+	} else {
+		resp.Result = result
+	}
+	return resp
+}
+
+// dispatch routes a request to its method handler
+func (s *MCPServer) dispatch(req rpcRequest) (any, *rpcError) {
+	switch req.Method {
+	case "initialize":
+		var params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		json.Unmarshal(req.Params, &params)
+		version := params.ProtocolVersion
+		if version == "" {
+			version = mcpProtocolVersion
+		}
+		return map[string]any{
+			"protocolVersion": version,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]any{"name": "ai-kanban-board", "version": "0.1.0"},
+		}, nil
+
+	case "notifications/initialized", "notifications/cancelled":
+		return nil, nil
+
+	case "ping":
+		return map[string]any{}, nil
+
+	case "tools/list":
+		return map[string]any{"tools": s.tools}, nil
+
+	case "tools/call":
+		var params struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: err.Error()}
+		}
+		return s.callTool(params.Name, params.Arguments)
+
+	case "":
+		return nil, &rpcError{Code: rpcInvalidRequest, Message: "missing method"}
+	}
+
+	return nil, &rpcError{Code: rpcMethodNotFound, Message: fmt.Sprintf("method %q not found", req.Method)}
+}
+
+// callTool runs a tool and wraps its output as MCP content
+// Tool failures are reported in the result (isError) so the agent can see them
+func (s *MCPServer) callTool(name string, args json.RawMessage) (any, *rpcError) {
+	for _, tool := range s.tools {
+		if tool.Name != name {
+			continue
+		}
+		if len(args) == 0 {
+			args = json.RawMessage("{}")
+		}
+
+		out, err := tool.handler(args)
+		if err != nil {
+			return mcpToolResult{Content: []mcpContent{{Type: "text", Text: err.Error()}}, IsError: true}, nil
+		}
+
+		text, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: err.Error()}
+		}
+		return mcpToolResult{Content: []mcpContent{{Type: "text", Text: string(text)}}}, nil
+	}
+
+	return nil, &rpcError{Code: rpcInvalidParams, Message: fmt.Sprintf("unknown tool %q", name)}
+}
+
+// registerTools returns the tool table exposed by this server
+func (s *MCPServer) registerTools() []mcpTool {
+	taskIDProp := map[string]any{"type": "string", "description": "Task ID"}
+
+	return []mcpTool{
+		{
+			Name:        "list_tasks",
+			Description: "List tasks on the board, optionally filtered by column (ID or title) or a text query.",
+			InputSchema: objectSchema(map[string]any{
+				"column": map[string]any{"type": "string", "description": "Column ID or title"},
+				"query":  map[string]any{"type": "string", "description": "Case-insensitive text to match in title or description"},
+			}),
+			handler: s.toolListTasks,
+		},
+		{
+			Name:        "get_task",
+			Description: "Get a single task with its agent state and logs.",
+			InputSchema: objectSchema(map[string]any{"task_id": taskIDProp}, "task_id"),
+			handler:     s.toolGetTask,
+		},
+		{
+			Name:        "claim_task",
+			Description: "Claim a task for an agent: marks the agent as running, optionally moves the card, and returns the column's step prompt.",
+			InputSchema: objectSchema(map[string]any{
+				"task_id":    taskIDProp,
+				"agent":      map[string]any{"type": "string", "description": "Agent type, e.g. claude-code, codex, gemini-cli"},
+				"session_id": map[string]any{"type": "string", "description": "Agent session ID to record"},
+				"column":     map[string]any{"type": "string", "description": "Column ID or title to move the card to"},
+			}, "task_id"),
+			handler: s.toolClaimTask,
+		},
+		{
+			Name:        "move_task",
+			Description: "Move a task to another column (ID or title, e.g. \"Review\").",
+			InputSchema: objectSchema(map[string]any{
+				"task_id": taskIDProp,
+				"column":  map[string]any{"type": "string", "description": "Column ID or title"},
+			}, "task_id", "column"),
+			handler: s.toolMoveTask,
+		},
+		{
+			Name:        "append_log",
+			Description: "Append a progress line to the task's agent log.",
+			InputSchema: objectSchema(map[string]any{
+				"task_id": taskIDProp,
+				"message": map[string]any{"type": "string", "description": "Log line to append"},
+			}, "task_id", "message"),
+			handler: s.toolAppendLog,
+		},
+		{
+			Name:        "add_comment",
+			Description: "Add a comment to the task's discussion thread, e.g. a summary of the work done.",
+			InputSchema: objectSchema(map[string]any{
+				"task_id": taskIDProp,
+				"text":    map[string]any{"type": "string", "description": "Comment text (markdown)"},
+				"author":  map[string]any{"type": "string", "description": "Who is commenting; defaults to the agent type"},
+			}, "task_id", "text"),
+			handler: s.toolAddComment,
+		},
+		{
+			Name:        "set_agent_status",
+			Description: "Set the task's agent status: idle, running, paused, completed or failed.",
+			InputSchema: objectSchema(map[string]any{
+				"task_id": taskIDProp,
+				"status": map[string]any{
+					"type": "string",
+					"enum": []string{string(AgentIdle), string(AgentRunning), string(AgentPaused), string(AgentCompleted), string(AgentFailed)},
+				},
+			}, "task_id", "status"),
+			handler: s.toolSetAgentStatus,
+		},
+	}
+}
+
+// objectSchema builds a JSON schema for an object with the given properties
+func objectSchema(props map[string]any, required ...string) map[string]any {
+	schema := map[string]any{"type": "object", "properties": props}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// decodeArgs unmarshals tool arguments
+func decodeArgs(args json.RawMessage, v any) error {
+	if err := json.Unmarshal(args, v); err != nil {
+		return fmt.Errorf("invalid arguments: %w", err)
+	}
+	return nil
+}
+
+// loadTask loads the board and finds a task by ID
+func (s *MCPServer) loadTask(taskID string) (*Board, *Task, error) {
+	if taskID == "" {
+		return nil, nil, errors.New("task_id is required")
+	}
+	board, err := s.backend.LoadBoard()
+	if err != nil {
+		return nil, nil, err
+	}
+	task := findTaskByID(board, taskID)
+	if task == nil {
+		return nil, nil, fmt.Errorf("task %s not found", taskID)
+	}
+	return board, task, nil
+}
+
+// toolListTasks implements list_tasks
+func (s *MCPServer) toolListTasks(args json.RawMessage) (any, error) {
+	var in struct {
+		Column string `json:"column"`
+		Query  string `json:"query"`
+	}
+	if err := decodeArgs(args, &in); err != nil {
+		return nil, err
+	}
+
+	board, err := s.backend.LoadBoard()
+	if err != nil {
+		return nil, err
+	}
+
+	columnID := ""
+	if in.Column != "" {
+		col := resolveColumn(board, in.Column)
+		if col == nil {
+			return nil, fmt.Errorf("unknown column %q", in.Column)
+		}
+		columnID = col.ID
+	}
+	query := strings.ToLower(in.Query)
+
+	// Summaries keep the listing small; get_task returns the full card
+	type taskSummary struct {
+		ID       string       `json:"id"`
+		Title    string       `json:"title"`
+		Column   string       `json:"column"`
+		Priority string       `json:"priority"`
+		Assignee string       `json:"assignee,omitempty"`
+		Agent    *AgentStatus `json:"agentStatus,omitempty"`
+	}
+	tasks := []taskSummary{}
+	for _, task := range board.Tasks {
+		if columnID != "" && task.ColumnID != columnID {
+			continue
+		}
+		if query != "" &&
+			!strings.Contains(strings.ToLower(task.Title), query) &&
+			!strings.Contains(strings.ToLower(task.Description), query) {
+			continue
+		}
+
+		summary := taskSummary{
+			ID:       task.ID,
+			Title:    task.Title,
+			Column:   task.ColumnID,
+			Priority: task.Priority.String(),
+			Assignee: task.Assignee,
+		}
+		if col := findColumnByID(board, task.ColumnID); col != nil {
+			summary.Column = col.Title
+		}
+		if task.Agent != nil {
+			summary.Agent = &task.Agent.Status
+		}
+		tasks = append(tasks, summary)
+	}
+
+	return tasks, nil
+}
+
+// toolGetTask implements get_task
+func (s *MCPServer) toolGetTask(args json.RawMessage) (any, error) {
+	var in struct {
+		TaskID string `json:"task_id"`
+	}
+	if err := decodeArgs(args, &in); err != nil {
+		return nil, err
+	}
+	_, task, err := s.loadTask(in.TaskID)
+	return task, err
+}
+
+// toolClaimTask implements claim_task
+func (s *MCPServer) toolClaimTask(args json.RawMessage) (any, error) {
+	var in struct {
+		TaskID    string `json:"task_id"`
+		Agent     string `json:"agent"`
+		SessionID string `json:"session_id"`
+		Column    string `json:"column"`
+	}
+	if err := decodeArgs(args, &in); err != nil {
+		return nil, err
+	}
+
+	board, task, err := s.loadTask(in.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	agentType := s.defaultAgent
+	if in.Agent != "" {
+		agentType = AgentType(in.Agent)
+	}
+	if task.Agent != nil && task.Agent.Status == AgentRunning && task.Agent.Type != agentType {
+		return nil, fmt.Errorf("task %s is already claimed by %s", task.ID, task.Agent.Type)
+	}
+
+	now := time.Now()
+	if task.Agent == nil || task.Agent.Type != agentType {
+		task.Agent = &AgentInfo{Type: agentType}
+	}
+	task.Agent.Status = AgentRunning
+	task.Agent.StartedAt = &now
+	if in.SessionID != "" {
+		task.Agent.SessionID = in.SessionID
+	}
+	if task.Assignee == "" {
+		task.Assignee = string(agentType)
+	}
+	task.UpdatedAt = now
+
+	if err := s.backend.UpdateTask(task); err != nil {
+		return nil, err
+	}
+
+	if in.Column != "" {
+		col := resolveColumn(board, in.Column)
+		if col == nil {
+			return nil, fmt.Errorf("unknown column %q", in.Column)
+		}
+		if err := s.backend.MoveTask(task.ID, col.ID); err != nil {
+			return nil, err
+		}
+		task.ColumnID = col.ID
+	}
+
+	// Hand the agent its instructions for the column the card is now in
+	return map[string]any{
+		"task":        task,
+		"step_prompt": buildTaskPrompt(task, findColumnByID(board, task.ColumnID), nil, board.Context),
+	}, nil
+}
+
+// toolMoveTask implements move_task
+func (s *MCPServer) toolMoveTask(args json.RawMessage) (any, error) {
+	var in struct {
+		TaskID string `json:"task_id"`
+		Column string `json:"column"`
+	}
+	if err := decodeArgs(args, &in); err != nil {
+		return nil, err
+	}
+
+	board, task, err := s.loadTask(in.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	col := resolveColumn(board, in.Column)
+	if col == nil {
+		return nil, fmt.Errorf("unknown column %q", in.Column)
+	}
+
+	if err := s.backend.MoveTask(task.ID, col.ID); err != nil {
+		return nil, err
+	}
+	task.ColumnID = col.ID
+	return task, nil
+}
+
+// toolAppendLog implements append_log
+func (s *MCPServer) toolAppendLog(args json.RawMessage) (any, error) {
+	var in struct {
+		TaskID  string `json:"task_id"`
+		Message string `json:"message"`
+	}
+	if err := decodeArgs(args, &in); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(in.Message) == "" {
+		return nil, errors.New("message is required")
+	}
+
+	_, task, err := s.loadTask(in.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.Agent == nil {
+		task.Agent = &AgentInfo{Type: s.defaultAgent, Status: AgentIdle}
+	}
+	task.Agent.Logs = append(task.Agent.Logs, in.Message)
+	task.UpdatedAt = time.Now()
+
+	if err := s.backend.UpdateTask(task); err != nil {
+		return nil, err
+	}
+	return task.Agent, nil
+}
+
+// toolAddComment implements add_comment
+func (s *MCPServer) toolAddComment(args json.RawMessage) (any, error) {
+	var in struct {
+		TaskID string `json:"task_id"`
+		Text   string `json:"text"`
+		Author string `json:"author"`
+	}
+	if err := decodeArgs(args, &in); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(in.Text) == "" {
+		return nil, errors.New("text is required")
+	}
+
+	_, task, err := s.loadTask(in.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := Comment{Author: in.Author, Text: in.Text, CreatedAt: time.Now()}
+	if comment.Author == "" {
+		comment.Author = string(s.defaultAgent)
+	}
+	if err := postComment(s.backend, task, comment); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// toolSetAgentStatus implements set_agent_status
+func (s *MCPServer) toolSetAgentStatus(args json.RawMessage) (any, error) {
+	var in struct {
+		TaskID string `json:"task_id"`
+		Status string `json:"status"`
+	}
+	if err := decodeArgs(args, &in); err != nil {
+		return nil, err
+	}
+
+	status := AgentStatus(in.Status)
+	switch status {
+	case AgentIdle, AgentRunning, AgentPaused, AgentCompleted, AgentFailed:
+	default:
+		return nil, fmt.Errorf("invalid status %q", in.Status)
+	}
+
+	_, task, err := s.loadTask(in.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if task.Agent == nil {
+		task.Agent = &AgentInfo{Type: s.defaultAgent}
+	}
+	task.Agent.Status = status
+	if status == AgentRunning && task.Agent.StartedAt == nil {
+		task.Agent.StartedAt = &now
+	}
+	task.UpdatedAt = now
+
+	if err := s.backend.UpdateTask(task); err != nil {
+		return nil, err
+	}
+	return task.Agent, nil
+}
+
+// runMCP implements the `mcp` subcommand
+func runMCP(args []string) {
+	fs := flag.NewFlagSet("mcp", flag.ExitOnError)
+	backendOpts := registerBackendFlags(fs)
+	agent := fs.String("agent", string(AgentClaudeCode), "Agent type recorded when claim_task doesn't specify one")
+	fs.Parse(args)
+
+	// stdout carries the protocol, so diagnostics go to stderr
+	server := NewMCPServer(backendOpts.openBackend(), AgentType(*agent))
+	if err := server.Serve(os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Error running MCP server: %v\n", err)
+		os.Exit(1)
+	}
+}
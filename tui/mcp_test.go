@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// mcpClient talks to an MCPServer over pipes the way a stdio client would
+type mcpClient struct {
+	t      *testing.T
+	in     *io.PipeWriter
+	out    *bufio.Scanner
+	nextID int
+}
+
+func newMCPClient(t *testing.T, server *MCPServer) *mcpClient {
+	clientIn, serverOut := io.Pipe()
+	serverIn, clientOut := io.Pipe()
+	go func() {
+		serverOut.CloseWithError(server.Serve(serverIn, serverOut))
+	}()
+	t.Cleanup(func() { clientOut.Close() })
+	return &mcpClient{t: t, in: clientOut, out: bufio.NewScanner(clientIn)}
+}
+
+// call sends a request and decodes the response's result
+func (c *mcpClient) call(method string, params any) json.RawMessage {
+	c.t.Helper()
+	c.nextID++
+	data, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": c.nextID, "method": method, "params": params})
+	if _, err := fmt.Fprintf(c.in, "%s\n", data); err != nil {
+		c.t.Fatal(err)
+	}
+	if !c.out.Scan() {
+		c.t.Fatalf("%s: no response (%v)", method, c.out.Err())
+	}
+	var resp struct {
+		ID     int             `json:"id"`
+		Result json.RawMessage `json:"result"`
+		Error  *rpcError       `json:"error"`
+	}
+	if err := json.Unmarshal(c.out.Bytes(), &resp); err != nil {
+		c.t.Fatal(err)
+	}
+	if resp.ID != c.nextID || resp.Error != nil {
+		c.t.Fatalf("%s: response %s", method, c.out.Bytes())
+	}
+	return resp.Result
+}
+
+// callTool calls a tool and returns its text content
+func (c *mcpClient) callTool(name string, args map[string]any) string {
+	c.t.Helper()
+	var result mcpToolResult
+	json.Unmarshal(c.call("tools/call", map[string]any{"name": name, "arguments": args}), &result)
+	if result.IsError || len(result.Content) != 1 {
+		c.t.Fatalf("%s failed: %+v", name, result)
+	}
+	return result.Content[0].Text
+}
+
+func TestMCPServerStdio(t *testing.T) {
+	backend := NewLocalBackend(filepath.Join(t.TempDir(), "board.yaml"))
+	board := &Board{ID: "board-1", Columns: []Column{
+		{ID: "todo", Title: "To Do"}, {ID: "doing", Title: "Doing", Prompt: "Implement it"}, {ID: "review", Title: "Review"},
+	}}
+	board.Tasks = []*Task{{ID: "t-1", Title: "Fix login", ColumnID: "todo"}}
+	if err := backend.SaveBoard(board); err != nil {
+		t.Fatal(err)
+	}
+	client := newMCPClient(t, NewMCPServer(backend, AgentClaudeCode))
+
+	var init struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	json.Unmarshal(client.call("initialize", map[string]any{"protocolVersion": mcpProtocolVersion}), &init)
+	if init.ProtocolVersion != mcpProtocolVersion {
+		t.Errorf("initialize returned version %q", init.ProtocolVersion)
+	}
+
+	var list struct {
+		Tools []mcpTool `json:"tools"`
+	}
+	json.Unmarshal(client.call("tools/list", nil), &list)
+	var names []string
+	for _, tool := range list.Tools {
+		names = append(names, tool.Name)
+	}
+	if !strings.Contains(strings.Join(names, ","), "claim_task,move_task,append_log") {
+		t.Errorf("tools/list returned %v", names)
+	}
+
+	claimed := client.callTool("claim_task", map[string]any{"task_id": "t-1", "column": "Doing"})
+	if !strings.Contains(claimed, "Implement it") || !strings.Contains(claimed, `"status": "running"`) {
+		t.Errorf("claim_task returned %s", claimed)
+	}
+	client.callTool("append_log", map[string]any{"task_id": "t-1", "message": "- tests pass"})
+	if moved := client.callTool("move_task", map[string]any{"task_id": "t-1", "column": "review"}); !strings.Contains(moved, `"columnId": "review"`) {
+		t.Errorf("move_task returned %s", moved)
+	}
+
+	board, err := backend.LoadBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	task := findTaskByID(board, "t-1")
+	if task.ColumnID != "review" || task.Assignee != string(AgentClaudeCode) {
+		t.Errorf("task is in %s assigned to %q", task.ColumnID, task.Assignee)
+	}
+	if task.Agent == nil || task.Agent.Status != AgentRunning || strings.Join(task.Agent.Logs, "|") != "- tests pass" {
+		t.Errorf("agent state %+v", task.Agent)
+	}
+}
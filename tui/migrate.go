@@ -0,0 +1,369 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// migrate.go - Board migration between YAML boards and beads
+// ai-kanban-tui migrate --to=beads copies every card of a YAML board into
+// beads issues; --to=yaml copies every beads issue (closed ones included)
+// into a YAML board. Titles, descriptions, priorities, types, labels,
+// assignees, estimates, due dates, dependencies, parents and status/column
+// carry over. Nothing is written without --apply, and an ID mapping table
+// (source and new IDs) is appended to --map so an interrupted migration can
+// be re-run without creating duplicates
+
+const (
+	migrateToBeads = "beads"
+	migrateToYAML  = "yaml"
+)
+
+// migrationItem is one card to migrate and where it goes
+type migrationItem struct {
+	task    *Task
+	status  string  // Beads status the card has or maps to
+	column  *Column // Target column
+	newID   string  // Set once created, or from an earlier run's map
+	created *Task   // The new card, when created by this run
+}
+
+// migration copies the cards of one backend into another
+type migration struct {
+	source, target           Backend
+	sourceBoard, targetBoard *Board
+	items                    []*migrationItem
+	ids                      map[string]string // Source ID -> target ID
+}
+
+// newMigration plans copying every card of source into target
+func newMigration(source, target Backend, sourceBoard, targetBoard *Board, ids map[string]string) *migration {
+	mg := &migration{source: source, target: target, sourceBoard: sourceBoard, targetBoard: targetBoard, ids: ids}
+	populateColumnTasks(sourceBoard)
+	for i := range sourceBoard.Columns {
+		col := &sourceBoard.Columns[i]
+		tasks := append([]*Task(nil), col.Tasks...)
+		sort.SliceStable(tasks, func(a, b int) bool { return tasks[a].Order < tasks[b].Order })
+		for _, task := range tasks {
+			status := mg.sourceStatus(task, i)
+			mg.items = append(mg.items, &migrationItem{
+				task:   task,
+				status: status,
+				column: mg.targetColumn(col, status),
+				newID:  ids[task.ID],
+			})
+		}
+	}
+	return mg
+}
+
+// sourceStatus returns a source card's beads status: its own on beads, else
+// its column's status, a built-in status its title names, or its place on
+// the board (first column open, last closed, the rest in progress)
+func (mg *migration) sourceStatus(task *Task, columnIndex int) string {
+	if beads, ok := mg.source.(*BeadsBackend); ok && beads.statuses[task.ID] != "" {
+		return beads.statuses[task.ID]
+	}
+	col := mg.sourceBoard.Columns[columnIndex]
+	if col.Status != "" {
+		return col.Status
+	}
+	for _, status := range beadsBuiltinStatuses {
+		if strings.EqualFold(col.Title, statusTitle(status)) {
+			return status
+		}
+	}
+	switch columnIndex {
+	case 0:
+		return StatusOpen
+	case len(mg.sourceBoard.Columns) - 1:
+		return StatusClosed
+	}
+	return StatusInProgress
+}
+
+// targetColumn picks the target column for a card: on YAML boards a column
+// with the same title first, then the column for its status
+func (mg *migration) targetColumn(sourceCol *Column, status string) *Column {
+	if _, ok := mg.target.(*BeadsBackend); !ok {
+		for i := range mg.targetBoard.Columns {
+			if strings.EqualFold(mg.targetBoard.Columns[i].Title, sourceCol.Title) {
+				return &mg.targetBoard.Columns[i]
+			}
+		}
+	}
+	return columnForStatus(mg.target, mg.targetBoard, status)
+}
+
+// columnForStatus returns the column cards with a beads status go to: on
+// beads the column showing it, on YAML boards a column with that status or
+// named after it ("In Progress"), else the first column for open cards, the
+// last for closed ones and the second for the rest
+func columnForStatus(backend Backend, board *Board, status string) *Column {
+	if _, ok := backend.(*BeadsBackend); ok {
+		return statusColumn(board, status)
+	}
+	for i := range board.Columns {
+		if board.Columns[i].Status == status {
+			return &board.Columns[i]
+		}
+	}
+	for i := range board.Columns {
+		if strings.EqualFold(board.Columns[i].Title, statusTitle(status)) {
+			return &board.Columns[i]
+		}
+	}
+	switch {
+	case status == StatusOpen || len(board.Columns) < 3:
+		return &board.Columns[0]
+	case status == StatusClosed:
+		return &board.Columns[len(board.Columns)-1]
+	}
+	return &board.Columns[1]
+}
+
+// migrationIssueType returns a card's issue type, task when its first label isn't one
+func migrationIssueType(task *Task) string {
+	if issueType := taskIssueType(task); issueType != "" {
+		return issueType
+	}
+	return "task"
+}
+
+// mapIDs maps source IDs to target IDs, dropping the ones not migrated
+func (mg *migration) mapIDs(sourceIDs []string) (mapped, missing []string) {
+	for _, id := range sourceIDs {
+		if newID := mg.ids[id]; newID != "" {
+			mapped = append(mapped, newID)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	return mapped, missing
+}
+
+// apply creates the cards not migrated yet, recording each new ID through
+// record, then copies their fields, dependencies and parents
+func (mg *migration) apply(record func(sourceID, targetID string) error) (created int, warnings []string, err error) {
+	beads, toBeads := mg.target.(*BeadsBackend)
+	var fresh []*migrationItem
+	for _, item := range mg.items {
+		if item.newID != "" {
+			continue // Migrated by an earlier run
+		}
+		task := item.task
+		columnID := item.column.ID
+		if toBeads && item.status == StatusClosed {
+			columnID = statusColumn(mg.targetBoard, StatusOpen).ID // Closed below, with its resolution
+		}
+		newTask, err := mg.target.CreateTask(task.Title, task.Description, columnID, migrationIssueType(task), task.Priority)
+		if err != nil {
+			return created, warnings, fmt.Errorf("creating %s: %w", task.ID, err)
+		}
+		if toBeads && item.status == StatusClosed {
+			if err := beads.CloseTask(newTask.ID, task.Resolution); err != nil {
+				warnings = append(warnings, fmt.Sprintf("%s was created as %s but not closed: %v", task.ID, newTask.ID, err))
+			}
+		}
+		item.newID, item.created = newTask.ID, newTask
+		mg.ids[task.ID] = newTask.ID
+		if err := record(task.ID, newTask.ID); err != nil {
+			return created, warnings, err
+		}
+		created++
+		fresh = append(fresh, item)
+	}
+
+	// Fields CreateTask doesn't take, once every blocker and parent exists
+	for _, item := range fresh {
+		task, source := item.created, item.task
+		blockedBy, missing := mg.mapIDs(source.BlockedBy)
+		for _, id := range missing {
+			warnings = append(warnings, fmt.Sprintf("%s: blocker %s isn't on the board, dependency dropped", source.ID, id))
+		}
+		task.Labels = append([]string{migrationIssueType(source)}, taskLabels(source)...)
+		task.Assignee = source.Assignee
+		task.Estimate = source.Estimate
+		task.DueDate = source.DueDate
+		task.BlockedBy = blockedBy
+		task.IsReady = len(blockedBy) == 0 && source.IsReady
+		task.Resolution = source.Resolution
+		task.ExternalID = source.ExternalID
+		if source.Parent != "" {
+			if parent := mg.ids[source.Parent]; parent != "" {
+				task.Parent = parent
+			} else {
+				warnings = append(warnings, fmt.Sprintf("%s: parent %s isn't on the board, left without one", source.ID, source.Parent))
+			}
+		}
+		if err := mg.target.UpdateTask(task); err != nil {
+			warnings = append(warnings, err.Error())
+		}
+
+		for _, comment := range mg.sourceComments(source) {
+			if err := postComment(mg.target, task, comment); err != nil {
+				warnings = append(warnings, fmt.Sprintf("%s: comment not copied: %v", source.ID, err))
+			}
+		}
+	}
+	return created, warnings, nil
+}
+
+// sourceComments returns a source card's comment thread
+func (mg *migration) sourceComments(task *Task) []Comment {
+	beads, ok := mg.source.(*BeadsBackend)
+	if !ok {
+		return task.Comments
+	}
+	details, err := beads.GetIssueDetails(task.ID)
+	if err != nil {
+		return nil
+	}
+	return taskComments(task, details)
+}
+
+// printPlan prints the mapping table: source ID, target ID (new when not
+// created yet), target column, type, priority and title
+func (mg *migration) printPlan() {
+	fmt.Printf("  %-22s %-22s %-16s %-8s %-4s %s\n", "SOURCE", "TARGET", "COLUMN", "TYPE", "PRI", "TITLE")
+	deps, parents := 0, 0
+	for _, item := range mg.items {
+		target := item.newID
+		if target == "" {
+			target = "(new)"
+		}
+		fmt.Printf("  %-22s %-22s %-16s %-8s %-4s %s\n", item.task.ID, target,
+			truncateText(item.column.Title, 16), migrationIssueType(item.task),
+			priorityShortLabel(item.task.Priority), truncateText(sanitizeLine(item.task.Title), 50))
+		deps += len(item.task.BlockedBy)
+		if item.task.Parent != "" {
+			parents++
+		}
+	}
+	fmt.Printf("\n  %d cards, %d dependencies, %d with a parent\n", len(mg.items), deps, parents)
+}
+
+// readMigrationMap reads an ID mapping table written by an earlier run
+func readMigrationMap(path string) (map[string]string, error) {
+	ids := make(map[string]string)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ids, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		source, target, ok := strings.Cut(line, "\t")
+		if ok && !strings.HasPrefix(line, "#") {
+			ids[source] = strings.TrimSpace(target)
+		}
+	}
+	return ids, nil
+}
+
+// runMigrate implements the migrate subcommand
+func runMigrate(args []string) {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	to := fs.String("to", "", "Where cards go: beads (from --board) or yaml (from beads into --board)")
+	boardFile := fs.String("board", "board.yaml", "YAML board to read (--to=beads) or write (--to=yaml)")
+	beadsJSONL := fs.Bool("beads-jsonl", false, "Read beads issues from "+beadsIssuesFile+" instead of bd list")
+	apply := fs.Bool("apply", false, "Migrate; without it only the plan is shown")
+	mapFile := fs.String("map", "migration-ids.tsv", "ID mapping table, appended to and read to skip cards already migrated")
+	fs.Parse(args)
+
+	beads := NewBeadsBackend()
+	beads.jsonlOnly = *beadsJSONL
+	beads.showAll = true // Closed issues are part of the board too
+	local := NewLocalBackend(*boardFile)
+
+	var source, target Backend
+	switch *to {
+	case migrateToBeads:
+		if _, err := os.Stat(*boardFile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		source, target = local, beads
+	case migrateToYAML:
+		source, target = beads, local
+	default:
+		fmt.Fprintln(os.Stderr, "Usage: ai-kanban-tui migrate --to=beads|yaml [--board=board.yaml] [--apply]")
+		os.Exit(2)
+	}
+
+	sourceBoard, err := source.LoadBoard()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading source board: %v\n", err)
+		os.Exit(1)
+	}
+	var targetBoard *Board
+	if _, err := os.Stat(*boardFile); target == local && os.IsNotExist(err) {
+		// A new YAML board gets the beads board's columns
+		targetBoard = &Board{ID: "board-1", Name: "Migrated from beads", Columns: append([]Column(nil), sourceBoard.Columns...)}
+		for i := range targetBoard.Columns {
+			targetBoard.Columns[i].Tasks = nil
+		}
+	} else if targetBoard, err = target.LoadBoard(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading target board: %v\n", err)
+		os.Exit(1)
+	}
+	if len(targetBoard.Columns) == 0 {
+		fmt.Fprintln(os.Stderr, "Error: the target board has no columns")
+		os.Exit(1)
+	}
+
+	ids, err := readMigrationMap(*mapFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", *mapFile, err)
+		os.Exit(1)
+	}
+	mg := newMigration(source, target, sourceBoard, targetBoard, ids)
+
+	targetName := *boardFile
+	if target == beads {
+		targetName = "beads"
+	}
+	if !*apply {
+		fmt.Printf("Dry run: migrating %d cards to %s (--apply to migrate)\n\n", len(mg.items), targetName)
+		mg.printPlan()
+		return
+	}
+
+	if target == local {
+		if _, err := os.Stat(*boardFile); os.IsNotExist(err) {
+			if err := local.SaveBoard(targetBoard); err != nil {
+				fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", *boardFile, err)
+				os.Exit(1)
+			}
+		}
+	}
+	f, err := os.OpenFile(*mapFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening %s: %v\n", *mapFile, err)
+		os.Exit(1)
+	}
+	defer f.Close()
+	w := bufio.NewWriter(f)
+	record := func(sourceID, targetID string) error {
+		if _, err := fmt.Fprintf(w, "%s\t%s\n", sourceID, targetID); err != nil {
+			return err
+		}
+		return w.Flush() // Written as we go, so a failed run can be resumed
+	}
+
+	created, warnings, err := mg.apply(record)
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+	mg.printPlan()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v (created %d, re-run to continue)\n", err, created)
+		os.Exit(1)
+	}
+	fmt.Printf("  Migrated %d cards to %s, ID map in %s\n", created, targetName, *mapFile)
+}
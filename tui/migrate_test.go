@@ -0,0 +1,58 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMigrateBeadsToYAML(t *testing.T) {
+	source := jsonlBackend()
+	source.showAll = true
+	sourceBoard, err := source.LoadBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := NewLocalBackend(filepath.Join(t.TempDir(), "board.yaml"))
+	targetBoard := &Board{ID: "board-1", Columns: []Column{
+		{ID: "todo", Title: "To Do"}, {ID: "doing", Title: "Doing"}, {ID: "done", Title: "Done"},
+	}}
+	if err := target.SaveBoard(targetBoard); err != nil {
+		t.Fatal(err)
+	}
+
+	ids := map[string]string{}
+	var recorded []string
+	mg := newMigration(source, target, sourceBoard, targetBoard, ids)
+	created, _, err := mg.apply(func(sourceID, targetID string) error {
+		recorded = append(recorded, sourceID+"="+targetID)
+		return nil
+	})
+	if err != nil || created != 4 || len(recorded) != 4 {
+		t.Fatalf("created %d (%v), %v", created, recorded, err)
+	}
+
+	board, err := target.LoadBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ui, rank, index := findTaskByID(board, ids["kb-3"]), findTaskByID(board, ids["kb-2"]), findTaskByID(board, ids["kb-1"])
+	if strings.Join(ui.BlockedBy, ",") != ids["kb-2"] {
+		t.Errorf("blocker not remapped: %v, want %s", ui.BlockedBy, ids["kb-2"])
+	}
+	if rank.Parent != ids["kb-epic"] || rank.Assignee != "ana" || rank.ColumnID != "doing" {
+		t.Errorf("kb-2 became %+v", rank)
+	}
+	if strings.Join(rank.Labels, ",") != "feature,backend,perf" {
+		t.Errorf("kb-2 labels %v", rank.Labels)
+	}
+	if index.ColumnID != "done" || index.Resolution != "Shipped in v2" {
+		t.Errorf("closed kb-1 is in %s resolved %q", index.ColumnID, index.Resolution)
+	}
+
+	// A second run finds everything migrated
+	mg = newMigration(source, target, sourceBoard, board, ids)
+	if created, _, err := mg.apply(func(string, string) error { return nil }); created != 0 || err != nil {
+		t.Errorf("second run created %d, %v", created, err)
+	}
+}
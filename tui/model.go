@@ -1,6 +1,7 @@
 package main
 
 import (
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
@@ -15,9 +16,22 @@ func NewModel(board *Board) Model {
 
 // NewModelWithBackend creates a new Model with a specific backend
 func NewModelWithBackend(board *Board, backend Backend) Model {
+	boardFile := "board.yaml"
+	beadsJSONL := false
+	switch b := backend.(type) {
+	case *LocalBackend:
+		boardFile = b.filePath
+	case *BeadsBackend:
+		beadsJSONL = b.jsonlOnly
+	}
+
 	return Model{
 		board:              board,
 		backend:            backend,
+		boardFile:          boardFile,
+		beadsJSONL:         beadsJSONL,
+		boardStates:        make(map[string]boardViewState),
+		agents:             newAgentManager(),
 		viewMode:           ViewBoard,
 		selectedColumn:     0,
 		selectedTask:       0,
@@ -27,6 +41,7 @@ func NewModelWithBackend(board *Board, backend Backend) Model {
 		ready:              false,
 		dropTargetColumn:   -1, // Initialize drop target as invalid
 		dropTargetIndex:    -1,
+		dropTargetLane:     -1,
 		columnScrollOffset: make(map[int]int),
 		// Responsive layout defaults
 		narrowMode:         false,
@@ -38,7 +53,8 @@ func NewModelWithBackend(board *Board, backend Backend) Model {
 
 // Init initializes the model (required by Bubbletea)
 func (m Model) Init() tea.Cmd {
-	return nil
+	// Start listening for events from agents launched by the TUI
+	return m.agents.waitForEvent()
 }
 
 // setSize updates the model dimensions and recalculates layout
@@ -163,6 +179,23 @@ func (m Model) getCurrentTask() *Task {
 	return nil
 }
 
+// selectTask moves the selection to a task's card, reporting whether it is on the board
+func (m *Model) selectTask(task *Task) bool {
+	for ci, col := range m.board.Columns {
+		for ti, t := range col.Tasks {
+			if t == task {
+				m.selectedColumn = ci
+				m.selectedTask = ti
+				m.ensureSelectedColumnVisible()
+				m.syncLaneToTask()
+				m.updateScrollOffset()
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // toggleDetails toggles the detail panel visibility
 func (m *Model) toggleDetails() {
 	m.showDetails = !m.showDetails
@@ -171,6 +204,10 @@ func (m *Model) toggleDetails() {
 
 // moveSelectionLeft moves the selection to the left column
 func (m *Model) moveSelectionLeft() {
+	if m.laneMode != LaneNone {
+		m.moveLaneSelectionHorizontal(-1)
+		return
+	}
 	if m.selectedColumn > 0 {
 		m.selectedColumn--
 		// Adjust task selection to stay within bounds
@@ -189,6 +226,10 @@ func (m *Model) moveSelectionLeft() {
 
 // moveSelectionRight moves the selection to the right column
 func (m *Model) moveSelectionRight() {
+	if m.laneMode != LaneNone {
+		m.moveLaneSelectionHorizontal(1)
+		return
+	}
 	if m.selectedColumn < len(m.board.Columns)-1 {
 		m.selectedColumn++
 		// Adjust task selection to stay within bounds
@@ -206,14 +247,24 @@ func (m *Model) moveSelectionRight() {
 }
 
 // moveSelectionUp moves the selection up within the current column
+// (or lane, crossing into the lane above)
 func (m *Model) moveSelectionUp() {
+	if m.laneMode != LaneNone {
+		m.moveLaneSelectionVertical(-1)
+		return
+	}
 	if m.selectedTask > 0 {
 		m.selectedTask--
 	}
 }
 
 // moveSelectionDown moves the selection down within the current column
+// (or lane, crossing into the lane below)
 func (m *Model) moveSelectionDown() {
+	if m.laneMode != LaneNone {
+		m.moveLaneSelectionVertical(1)
+		return
+	}
 	col := m.getCurrentColumn()
 	if col != nil && m.selectedTask < len(col.Tasks)-1 {
 		m.selectedTask++
@@ -290,6 +341,11 @@ func (m *Model) moveTask(fromColIndex, fromTaskIndex, toColIndex, insertIndex in
 	// Get the task to move
 	task := fromCol.Tasks[fromTaskIndex]
 
+	// Moving into done asks for a resolution first
+	if fromColIndex != toColIndex && m.promptResolution([]*Task{task}, toCol.ID) {
+		return
+	}
+
 	// Handle reordering within the same column
 	if fromColIndex == toColIndex {
 		// Check if actually moving to a different position
@@ -333,6 +389,9 @@ func (m *Model) moveTask(fromColIndex, fromTaskIndex, toColIndex, insertIndex in
 		// Update task's column field
 		task.ColumnID = toCol.ID
 		m.selectedColumn = toColIndex
+		if !m.isDoneColumn(toCol.ID) {
+			task.Resolution = "" // Reopened
+		}
 	}
 
 	// Update modification time
@@ -357,8 +416,8 @@ func (m *Model) openCreateTaskForm() {
 
 	// Create text inputs for the form
 	titleInput := textinput.New()
-	titleInput.Placeholder = "Task title"
-	titleInput.CharLimit = 100
+	titleInput.Placeholder = "Task title  !p1 #bug +label @who ^blocker due:fri ~2h"
+	titleInput.CharLimit = 200 // Room for quick-capture tokens
 	titleInput.Width = 40
 	titleInput.Focus()
 
@@ -367,7 +426,7 @@ func (m *Model) openCreateTaskForm() {
 	descInput.CharLimit = 500
 	descInput.Width = 40
 
-	m.formInputs = []textinput.Model{titleInput, descInput}
+	m.formInputs = []textinput.Model{titleInput, descInput, newLabelsInput(nil)}
 }
 
 // openEditTaskForm opens the form for editing the selected task
@@ -381,13 +440,10 @@ func (m *Model) openEditTaskForm() {
 	m.editingTaskID = task.ID
 	m.formFocusIndex = 0
 
-	// Load type from labels (first label is typically issue type)
-	m.formIssueType = "task"
-	if len(task.Labels) > 0 {
-		switch task.Labels[0] {
-		case "bug", "feature", "task":
-			m.formIssueType = task.Labels[0]
-		}
+	// Load type from labels (first label is the issue type when it names one)
+	m.formIssueType = taskIssueType(task)
+	if m.formIssueType == "" {
+		m.formIssueType = "task"
 	}
 	m.formPriority = task.Priority
 
@@ -405,7 +461,17 @@ func (m *Model) openEditTaskForm() {
 	descInput.Width = 40
 	descInput.SetValue(task.Description)
 
-	m.formInputs = []textinput.Model{titleInput, descInput}
+	m.formInputs = []textinput.Model{titleInput, descInput, newLabelsInput(taskLabels(task))}
+}
+
+// newLabelsInput creates the form's labels input
+func newLabelsInput(labels []string) textinput.Model {
+	labelsInput := textinput.New()
+	labelsInput.Placeholder = "Labels, comma separated (optional)"
+	labelsInput.CharLimit = 200
+	labelsInput.Width = 40
+	labelsInput.SetValue(strings.Join(labels, ", "))
+	return labelsInput
 }
 
 // closeTaskForm closes the task form without saving
@@ -463,6 +529,10 @@ func (m *Model) saveTaskForm() {
 
 	title := m.formInputs[0].Value()
 	description := m.formInputs[1].Value()
+	var labels []string
+	if len(m.formInputs) > 2 {
+		labels = parseLabelList(m.formInputs[2].Value())
+	}
 
 	// Don't save empty titles
 	if title == "" {
@@ -471,13 +541,31 @@ func (m *Model) saveTaskForm() {
 	}
 
 	if m.formMode == FormCreateTask {
-		// Create new task with selected type and priority
+		// Create new task; title tokens override the selected type and priority
+		capture := parseCapture(title, time.Now())
+		if capture.title == "" {
+			m.statusMessage = "The title is only capture tokens"
+			return
+		}
+		if capture.issueType == "" {
+			capture.issueType = m.formIssueType
+		}
+		if !capture.hasPriority {
+			capture.priority = m.formPriority
+		}
+		for _, label := range labels {
+			capture.addLabel(label)
+		}
 		col := m.getCurrentColumn()
 		if col != nil {
-			task, err := m.backend.CreateTask(title, description, col.ID, m.formIssueType, m.formPriority)
-			if err == nil {
+			task, err := capture.createTask(m.backend, col.ID, description)
+			if task != nil {
 				col.Tasks = append(col.Tasks, task)
 				m.board.Tasks = append(m.board.Tasks, task)
+				m.attachToFocusedEpic(task)
+			}
+			if err != nil {
+				m.statusMessage = err.Error()
 			}
 		}
 	} else if m.formMode == FormEditTask {
@@ -487,14 +575,12 @@ func (m *Model) saveTaskForm() {
 				task.Title = title
 				task.Description = description
 				task.Priority = m.formPriority
-				// Preserve labels beyond the issue type (first label)
-				if len(task.Labels) > 1 {
-					task.Labels = append([]string{m.formIssueType}, task.Labels[1:]...)
-				} else {
-					task.Labels = []string{m.formIssueType}
-				}
+				// The issue type is the first label
+				task.Labels = append([]string{m.formIssueType}, labels...)
 				task.UpdatedAt = time.Now()
-				m.backend.UpdateTask(task)
+				if err := m.backend.UpdateTask(task); err != nil {
+					m.statusMessage = err.Error()
+				}
 				break
 			}
 		}
@@ -505,25 +591,13 @@ func (m *Model) saveTaskForm() {
 
 // toggleBackend switches between beads and local backends
 func (m *Model) toggleBackend() {
-	// Check what backend we're currently using
-	_, isBeads := m.backend.(*BeadsBackend)
-
-	if isBeads {
-		// Switch to local backend
-		m.backend = NewLocalBackend("board.yaml")
-	} else {
-		// Switch to beads backend
-		m.backend = NewBeadsBackend()
+	entry := BoardEntry{Key: beadsBoardKey, IsBeads: true}
+	if m.isBeadsBackend() {
+		// Switch back to the local board file the user opened
+		entry = BoardEntry{Key: m.boardFile, Path: m.boardFile}
 	}
-
-	// Reload the board with new backend
-	board, err := m.backend.LoadBoard()
-	if err == nil {
-		m.board = board
-		m.selectedColumn = 0
-		m.selectedTask = 0
-		m.cachedIssueDetails = nil
-		m.cachedIssueID = ""
+	if err := m.switchBoard(entry); err != nil {
+		m.statusMessage = "Switch failed: " + err.Error()
 	}
 }
 
@@ -553,6 +627,20 @@ func (m *Model) toggleShowAll() {
 	}
 }
 
+// reloadBoard replaces the board with the backend's copy, e.g. after a change
+// failed half way, keeping the selection in range
+func (m *Model) reloadBoard() {
+	board, err := m.backend.LoadBoard()
+	if err != nil {
+		return
+	}
+	m.board = board
+	if m.selectedColumn >= len(board.Columns) {
+		m.selectedColumn = 0
+	}
+	m.clampSelection()
+}
+
 // isShowingAll returns true if showing closed issues
 func (m Model) isShowingAll() bool {
 	beadsBackend, ok := m.backend.(*BeadsBackend)
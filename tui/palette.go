@@ -0,0 +1,200 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// palette.go - Command palette
+// ctrl+k or : opens a fuzzy search over registered commands and the board's
+// tasks; choosing a command runs it, choosing a task jumps to its card
+
+// paletteMaxItems is how many matches the palette shows at once
+const paletteMaxItems = 12
+
+// paletteItem is a command or a task in the palette
+type paletteItem struct {
+	command *Command
+	task    *Task
+	column  int // Column index of task
+	row     int // Row of task within its column
+}
+
+// openPalette shows the command palette
+func (m *Model) openPalette() {
+	m.paletteActive = true
+	m.paletteIndex = 0
+	m.paletteInput = textinput.New()
+	m.paletteInput.Placeholder = "Search actions and tasks..."
+	m.paletteInput.CharLimit = 100
+	m.paletteInput.Width = 50
+	m.paletteInput.Focus()
+}
+
+// closePalette hides the command palette
+func (m *Model) closePalette() {
+	m.paletteActive = false
+}
+
+// paletteItems returns the commands and tasks matching the query, best first
+// With an empty query commands come first, in registry order, then tasks
+func (m Model) paletteItems() []paletteItem {
+	query := strings.TrimSpace(m.paletteInput.Value())
+
+	type scored struct {
+		item  paletteItem
+		score int
+	}
+	var matches []scored
+
+	for i := range commands {
+		c := &commands[i]
+		if c.Run == nil || c.ID == "palette.open" {
+			continue
+		}
+		if score, ok := fuzzyScore(query, c.Title+" "+c.ID); ok {
+			matches = append(matches, scored{paletteItem{command: c}, score})
+		}
+	}
+
+	for ci, col := range m.board.Columns {
+		for ri, task := range col.Tasks {
+			if score, ok := fuzzyScore(query, task.ID+" "+task.Title); ok {
+				matches = append(matches, scored{paletteItem{task: task, column: ci, row: ri}, score})
+			}
+		}
+	}
+
+	if query != "" {
+		sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+	}
+
+	items := make([]paletteItem, len(matches))
+	for i, s := range matches {
+		items[i] = s.item
+	}
+	return items
+}
+
+// choosePaletteItem runs the highlighted command or jumps to the highlighted task
+func (m *Model) choosePaletteItem() tea.Cmd {
+	items := m.paletteItems()
+	if m.paletteIndex < 0 || m.paletteIndex >= len(items) {
+		m.closePalette()
+		return nil
+	}
+	item := items[m.paletteIndex]
+	m.closePalette()
+
+	if item.command != nil {
+		return item.command.Run(m)
+	}
+
+	m.viewMode = ViewBoard
+	m.selectedColumn = item.column
+	m.selectedTask = item.row
+	m.ensureSelectedColumnVisible()
+	m.updateScrollOffset()
+	m.fetchIssueDetails()
+	return nil
+}
+
+// handlePaletteKeyMsg handles keyboard input when the command palette is open
+func (m Model) handlePaletteKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.closePalette()
+		return m, nil
+
+	case "up", "ctrl+p", "ctrl+k":
+		if m.paletteIndex > 0 {
+			m.paletteIndex--
+		}
+		return m, nil
+
+	case "down", "ctrl+n", "ctrl+j":
+		if m.paletteIndex < len(m.paletteItems())-1 {
+			m.paletteIndex++
+		}
+		return m, nil
+
+	case "enter":
+		cmd = m.choosePaletteItem()
+		return m, cmd
+	}
+
+	// Update the search input and start again from the best match
+	m.paletteInput, cmd = m.paletteInput.Update(msg)
+	m.paletteIndex = 0
+	return m, cmd
+}
+
+// renderPalette renders the command palette overlay
+func (m Model) renderPalette(background string) string {
+	var content strings.Builder
+	content.WriteString(styleDetailTitle.Render("Command Palette"))
+	content.WriteString("\n\n")
+	content.WriteString(m.paletteInput.View())
+	content.WriteString("\n\n")
+
+	items := m.paletteItems()
+	if len(items) == 0 {
+		content.WriteString(styleSubdued.Render("No matching actions or tasks"))
+		content.WriteString("\n")
+	}
+
+	// Keep the highlighted item in the visible window
+	start := 0
+	if m.paletteIndex >= paletteMaxItems {
+		start = m.paletteIndex - paletteMaxItems + 1
+	}
+	end := start + paletteMaxItems
+	if end > len(items) {
+		end = len(items)
+	}
+
+	for i := start; i < end; i++ {
+		item := items[i]
+		var line string
+		if item.command != nil {
+			line = fmt.Sprintf("%-40s %14s", truncateText(item.command.Title, 40), truncateText(item.command.keyHint(), 14))
+		} else {
+			column := m.board.Columns[item.column].Title
+			title := truncateText(sanitizeLine(item.task.ID+" "+item.task.Title), 40)
+			line = fmt.Sprintf("%-40s %14s", title, truncateText(column, 14))
+		}
+
+		if i == m.paletteIndex {
+			content.WriteString(styleFormSelected.Render(line))
+		} else if item.command != nil {
+			content.WriteString(styleDetailValue.Render(line))
+		} else {
+			content.WriteString(styleFormOption.Render(line))
+		}
+		content.WriteString("\n")
+	}
+
+	if len(items) > end {
+		content.WriteString(styleSubdued.Render(fmt.Sprintf("  ... %d more", len(items)-end)))
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	content.WriteString(styleSubdued.Render("↑/↓: Select | Enter: Run / Jump to task | Esc: Cancel"))
+
+	overlay := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorPrimary).
+		Padding(1, 2).
+		Width(64).
+		Render(content.String())
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay)
+}
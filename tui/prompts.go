@@ -0,0 +1,239 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+)
+
+// prompts.go - Per-column step prompts
+// Each column can carry a prompt template that is rendered with the task's
+// fields and sent to agents and the chat popup for cards in that column
+
+// defaultStepPrompt is used when a column has no prompt of its own
+const defaultStepPrompt = "Help me with this task."
+
+// promptPlaceholders lists the placeholders available in step prompts (shown in the editor)
+var promptPlaceholders = []string{
+	"{{id}}", "{{title}}", "{{description}}", "{{priority}}", "{{type}}",
+	"{{labels}}", "{{assignee}}", "{{column}}", "{{blocked_by}}", "{{blocking}}",
+	"{{branch}}", "{{worktree}}", "{{pr}}",
+}
+
+// taskPromptValues returns the placeholder values for a task
+// Dependencies come from beads details when available, else from the task itself
+func taskPromptValues(task *Task, col *Column, details *BeadsIssueDetails) map[string]string {
+	values := map[string]string{
+		"id":          task.ID,
+		"title":       task.Title,
+		"description": task.Description,
+		"priority":    task.Priority.String(),
+		"labels":      strings.Join(task.Labels, ", "),
+		"assignee":    task.Assignee,
+		"blocked_by":  strings.Join(task.BlockedBy, ", "),
+		"blocking":    strings.Join(task.Blocking, ", "),
+	}
+
+	if len(task.Labels) > 0 {
+		values["type"] = task.Labels[0]
+	}
+	if col != nil {
+		values["column"] = col.Title
+	}
+
+	if details != nil {
+		var blockedBy, blocking []string
+		for _, dep := range details.Blockers() {
+			blockedBy = append(blockedBy, dep.ID)
+		}
+		for _, dep := range details.Blocked() {
+			blocking = append(blocking, dep.ID)
+		}
+		if len(blockedBy) > 0 {
+			values["blocked_by"] = strings.Join(blockedBy, ", ")
+		}
+		if len(blocking) > 0 {
+			values["blocking"] = strings.Join(blocking, ", ")
+		}
+	}
+
+	if task.Git != nil {
+		values["branch"] = task.Git.Branch
+		values["worktree"] = task.Git.Worktree
+		if task.Git.PRUrl != "" {
+			values["pr"] = task.Git.PRUrl
+		} else if task.Git.PRNumber != 0 {
+			values["pr"] = "#" + strconv.Itoa(task.Git.PRNumber)
+		}
+	}
+
+	return values
+}
+
+// renderStepPrompt fills a prompt template's {{placeholders}} with task values
+// Unknown placeholders are left as-is so typos are visible in the output
+func renderStepPrompt(tmpl string, task *Task, col *Column, details *BeadsIssueDetails) string {
+	values := taskPromptValues(task, col, details)
+
+	// Values come from issue text, so strip control characters (and newlines
+	// outside the description) before they reach an agent
+	var pairs []string
+	for key, value := range values {
+		if key == "description" {
+			value = sanitizeText(value)
+		} else {
+			value = sanitizeLine(value)
+		}
+		pairs = append(pairs, "{{"+key+"}}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(tmpl)
+}
+
+// buildTaskContext builds the task summary sent ahead of the step prompt
+// cfg selects the optional sections (nil for the defaults); all task text is
+// sanitized since it may come from other people's issues
+func buildTaskContext(task *Task, details *BeadsIssueDetails, cfg *ContextConfig) string {
+	var contextParts []string
+
+	// Add issue ID if it looks like a beads ID
+	if strings.HasPrefix(task.ID, "ai-kanban-board-") {
+		contextParts = append(contextParts, fmt.Sprintf("Issue: %s", sanitizeLine(task.ID)))
+	}
+
+	contextParts = append(contextParts, fmt.Sprintf("Title: %s", sanitizeLine(task.Title)))
+
+	if task.Description != "" && cfg.includes(contextDescription) {
+		contextParts = append(contextParts, fmt.Sprintf("Description: %s", sanitizeText(task.Description)))
+	}
+
+	contextParts = append(contextParts, fmt.Sprintf("Priority: %s", task.Priority.String()))
+
+	if len(task.Labels) > 0 {
+		contextParts = append(contextParts, fmt.Sprintf("Type: %s", sanitizeLine(task.Labels[0])))
+	}
+	if task.Parent != "" {
+		contextParts = append(contextParts, fmt.Sprintf("Epic: %s", sanitizeLine(task.Parent)))
+	}
+
+	// Add dependencies, from beads details when available
+	if cfg.includes(contextDependencies) {
+		blockedBy, blocking := task.BlockedBy, task.Blocking
+		if details != nil {
+			blockedBy, blocking = nil, nil
+			for _, dep := range details.Blockers() {
+				blockedBy = append(blockedBy, fmt.Sprintf("%s (%s)", dep.ID, dep.Title))
+			}
+			for _, dep := range details.Blocked() {
+				blocking = append(blocking, fmt.Sprintf("%s (%s)", dep.ID, dep.Title))
+			}
+		}
+		if len(blockedBy) > 0 {
+			contextParts = append(contextParts, fmt.Sprintf("Blocked by: %s", sanitizeLine(strings.Join(blockedBy, ", "))))
+		}
+		if len(blocking) > 0 {
+			contextParts = append(contextParts, fmt.Sprintf("Blocks: %s", sanitizeLine(strings.Join(blocking, ", "))))
+		}
+	}
+
+	if task.Git != nil && cfg.includes(contextGit) {
+		if task.Git.Branch != "" {
+			contextParts = append(contextParts, fmt.Sprintf("Branch: %s", sanitizeLine(task.Git.Branch)))
+		}
+		if task.Git.Worktree != "" {
+			contextParts = append(contextParts, fmt.Sprintf("Worktree: %s", sanitizeLine(task.Git.Worktree)))
+		}
+	}
+
+	// Recent history, oldest first
+	if len(task.History) > 0 && cfg.includes(contextHistory) {
+		start := len(task.History) - cfg.historyLimit()
+		if start < 0 {
+			start = 0
+		}
+		lines := []string{"Recent history:"}
+		for _, entry := range task.History[start:] {
+			line := entry.Event
+			if entry.To != "" {
+				line += fmt.Sprintf(" %s -> %s", entry.From, entry.To)
+			}
+			if entry.Detail != "" {
+				line += " (" + entry.Detail + ")"
+			}
+			lines = append(lines, fmt.Sprintf("- %s %s", entry.At.Format("2006-01-02 15:04"), sanitizeLine(line)))
+		}
+		contextParts = append(contextParts, strings.Join(lines, "\n"))
+	}
+
+	// Files changed in the task's worktree
+	if cfg.includes(contextFiles) {
+		if files := worktreeFiles(task.Git, cfg.maxFiles()); len(files) > 0 {
+			contextParts = append(contextParts, "Changed files:\n- "+strings.Join(files, "\n- "))
+		}
+	}
+
+	return strings.Join(contextParts, "\n")
+}
+
+// buildTaskPrompt builds the full prompt for a task: its context followed by
+// the column's rendered step prompt (or the default prompt)
+func buildTaskPrompt(task *Task, col *Column, details *BeadsIssueDetails, cfg *ContextConfig) string {
+	stepPrompt := defaultStepPrompt
+	if col != nil && strings.TrimSpace(col.Prompt) != "" {
+		stepPrompt = renderStepPrompt(col.Prompt, task, col, details)
+	}
+
+	return fmt.Sprintf("I'm working on this task from my kanban board:\n\n%s\n\n%s",
+		buildTaskContext(task, details, cfg), stepPrompt)
+}
+
+// columnForTask returns the column a task is in, or nil
+func (m Model) columnForTask(task *Task) *Column {
+	if task == nil {
+		return nil
+	}
+	return findColumnByID(m.board, task.ColumnID)
+}
+
+// openPromptEditor opens the step prompt editor for the selected column
+func (m *Model) openPromptEditor() {
+	col := m.getCurrentColumn()
+	if col == nil {
+		return
+	}
+
+	editor := textarea.New()
+	editor.Placeholder = "Step prompt for cards in this column, e.g. Review {{title}} on {{branch}}"
+	editor.ShowLineNumbers = false
+	editor.CharLimit = 4000
+	editor.SetWidth(60)
+	editor.SetHeight(8)
+	editor.SetValue(col.Prompt)
+	editor.Focus()
+
+	m.promptEditor = editor
+	m.promptEditorColumn = col.ID
+	m.promptEditorActive = true
+}
+
+// savePromptEditor stores the edited prompt on the column and persists the board
+func (m *Model) savePromptEditor() {
+	if col := findColumnByID(m.board, m.promptEditorColumn); col != nil {
+		previous := col.Prompt
+		col.Prompt = strings.TrimSpace(m.promptEditor.Value())
+		if m.backend != nil {
+			if err := m.backend.SaveBoard(m.board); err != nil {
+				col.Prompt = previous
+				m.statusMessage = "Prompt not saved: " + err.Error()
+			}
+		}
+	}
+	m.closePromptEditor()
+}
+
+// closePromptEditor closes the step prompt editor without saving
+func (m *Model) closePromptEditor() {
+	m.promptEditorActive = false
+	m.promptEditorColumn = ""
+}
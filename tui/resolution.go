@@ -0,0 +1,231 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// resolution.go - Resolution prompt for closed cards
+// Moving cards into the done (last) column, closing them in a batch or
+// closing a beads issue first asks how they were resolved: done, won't fix,
+// duplicate of <id> or obsolete, plus free text. The resolution is kept on
+// the card (close_reason in beads), shown in the detail panel and matched by
+// the filter, with resolution:<kind> for one kind
+
+// resolutionKind is a choice of the resolution prompt
+type resolutionKind struct {
+	key   string // Used by resolution:<key> filters
+	label string // Start of the stored resolution
+}
+
+// resolutionKinds are the resolutions offered, in prompt order
+var resolutionKinds = []resolutionKind{
+	{key: "done", label: "done"},
+	{key: "wontfix", label: "won't fix"},
+	{key: "duplicate", label: "duplicate of"},
+	{key: "obsolete", label: "obsolete"},
+}
+
+// Indexes of the resolutions in resolutionKinds
+const (
+	resolutionDone      = 0
+	resolutionWontFix   = 1
+	resolutionDuplicate = 2
+	resolutionObsolete  = 3
+)
+
+// formatResolution builds the stored resolution: "won't fix: too risky",
+// "duplicate of kb-3", "done"
+func formatResolution(kind int, text string) string {
+	label := resolutionKinds[kind].label
+	if kind == resolutionDuplicate {
+		return label + " " + text
+	}
+	if text == "" {
+		return label
+	}
+	return label + ": " + text
+}
+
+// resolutionKey returns the kind of a stored resolution, or "" for free text
+// (close reasons written outside the TUI)
+func resolutionKey(resolution string) string {
+	lower := strings.ToLower(resolution)
+	for _, kind := range resolutionKinds {
+		if strings.HasPrefix(lower, kind.label) {
+			return kind.key
+		}
+	}
+	if strings.HasPrefix(lower, "wontfix") {
+		return "wontfix"
+	}
+	return ""
+}
+
+// promptResolution opens the resolution prompt when tasks are moved into the
+// done column, reporting whether the caller should leave the move to it
+func (m *Model) promptResolution(tasks []*Task, columnID string) bool {
+	if !m.isDoneColumn(columnID) {
+		return false
+	}
+	var ids []string
+	for _, task := range tasks {
+		if !m.isDoneColumn(task.ColumnID) {
+			ids = append(ids, task.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return false
+	}
+
+	m.resolveTaskIDs = ids
+	m.resolveColumnID = columnID
+	m.resolveKind = 0
+	m.resolveInput = textinput.New()
+	m.resolveInput.CharLimit = 200
+	m.resolveInput.Width = 40
+	m.resolveInput.Focus()
+	m.updateResolvePlaceholder()
+	m.resolveActive = true
+	return true
+}
+
+// updateResolvePlaceholder describes the text the chosen kind expects
+func (m *Model) updateResolvePlaceholder() {
+	if m.resolveKind == resolutionDuplicate {
+		m.resolveInput.Placeholder = "ID of the issue it duplicates"
+	} else {
+		m.resolveInput.Placeholder = "Details (optional)"
+	}
+}
+
+// confirmResolution closes the pending cards with the chosen resolution
+func (m *Model) confirmResolution() {
+	text := strings.TrimSpace(m.resolveInput.Value())
+	if m.resolveKind == resolutionDuplicate {
+		if text == "" {
+			m.statusMessage = "Enter the ID of the issue it duplicates"
+			return
+		}
+		if containsString(m.resolveTaskIDs, text) {
+			m.statusMessage = "A card can't duplicate itself"
+			return
+		}
+	}
+	m.resolveActive = false
+
+	var tasks []*Task
+	for _, id := range m.resolveTaskIDs {
+		if task := findTaskByID(m.board, id); task != nil {
+			tasks = append(tasks, task)
+		}
+	}
+	m.resolveTasks(tasks, m.resolveColumnID, formatResolution(m.resolveKind, text))
+}
+
+// resolveTasks records a resolution on tasks and moves them to the done
+// column; beads issues are closed with it as the close reason
+func (m *Model) resolveTasks(tasks []*Task, columnID, resolution string) {
+	beads, isBeads := m.backend.(*BeadsBackend)
+	failed := 0
+	var saveErr error
+	for _, task := range tasks {
+		task.Resolution = resolution
+		task.UpdatedAt = time.Now()
+		if !isBeads {
+			n, err := m.moveTasksTo([]*Task{task}, columnID)
+			failed += n
+			if err != nil {
+				saveErr = err
+			}
+			continue
+		}
+		if err := beads.CloseTask(task.ID, resolution); err != nil {
+			m.statusMessage = err.Error()
+			failed++
+			continue
+		}
+		m.relocateTask(task, columnID)
+	}
+	if len(tasks) == 0 || failed == len(tasks) {
+		return
+	}
+
+	m.selectTask(tasks[0])
+	m.cachedIssueID = "" // Status changed, refetch details
+	m.fetchIssueDetails()
+	m.statusMessage = fmt.Sprintf("Closed %d as %s", len(tasks)-failed, resolution)
+	if failed > 0 {
+		m.statusMessage += fmt.Sprintf(", %d failed", failed)
+	}
+	m.statusMessage += saveFailure(saveErr)
+}
+
+// handleResolutionKeyMsg handles keyboard input while the resolution prompt is open
+func (m Model) handleResolutionKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.resolveActive = false // The cards stay where they were
+		return m, nil
+	case "enter":
+		m.confirmResolution()
+		return m, nil
+	case "tab", "down":
+		m.resolveKind = (m.resolveKind + 1) % len(resolutionKinds)
+		m.updateResolvePlaceholder()
+		return m, nil
+	case "shift+tab", "up":
+		m.resolveKind = (m.resolveKind + len(resolutionKinds) - 1) % len(resolutionKinds)
+		m.updateResolvePlaceholder()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.resolveInput, cmd = m.resolveInput.Update(msg)
+	return m, cmd
+}
+
+// renderResolutionPrompt renders the resolution prompt overlay
+func (m Model) renderResolutionPrompt(background string) string {
+	var content strings.Builder
+	title := fmt.Sprintf("Close %d cards", len(m.resolveTaskIDs))
+	if len(m.resolveTaskIDs) == 1 {
+		title = "Close " + m.resolveTaskIDs[0]
+		if task := findTaskByID(m.board, m.resolveTaskIDs[0]); task != nil {
+			title += ": " + truncateText(sanitizeLine(task.Title), 36)
+		}
+	}
+	content.WriteString(styleDetailTitle.Render(title))
+	content.WriteString("\n\n")
+
+	content.WriteString(styleDetailLabel.Render("Resolution:"))
+	content.WriteString("\n")
+	for i, kind := range resolutionKinds {
+		if i == m.resolveKind {
+			content.WriteString(styleFormSelected.Render(kind.label))
+		} else {
+			content.WriteString(styleFormOption.Render(kind.label))
+		}
+		if i < len(resolutionKinds)-1 {
+			content.WriteString("  ")
+		}
+	}
+	content.WriteString("\n\n")
+	content.WriteString(m.resolveInput.View())
+	content.WriteString("\n\n")
+	content.WriteString(styleSubdued.Render("Tab: Resolution | Enter: Close | Esc: Cancel"))
+
+	overlay := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorPrimary).
+		Padding(1, 2).
+		Width(56).
+		Render(content.String())
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay)
+}
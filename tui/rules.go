@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// rules.go - Auto-advance pipeline rules
+// Columns can declare where a card goes when the agent working it finishes;
+// rules are evaluated when a TUI-managed agent exits and every move is
+// recorded in the task's history
+
+// Special rule targets (anything else is a column ID or title)
+const (
+	ruleTargetNext = "next" // Following column (default for on_complete)
+	ruleTargetPrev = "prev" // Preceding column
+	ruleTargetStay = "stay" // Leave the card where it is (default for on_fail)
+)
+
+// historyAutoAdvance is the history event recorded for rule-driven moves
+const historyAutoAdvance = "auto-advance"
+
+// maxHistoryEntries caps how many history entries are kept per task
+const maxHistoryEntries = 50
+
+// advanceAction is the outcome of evaluating a column's rule for a task
+type advanceAction struct {
+	TaskID   string
+	From     *Column
+	To       *Column // nil when the card stays put
+	AddLabel string  // Label to add (on failure)
+	Reason   string  // e.g. "agent completed"
+}
+
+// isNoop reports whether the action neither moves nor tags the card
+func (a advanceAction) isNoop() bool {
+	return a.To == nil && a.AddLabel == ""
+}
+
+// describe returns a one-line summary of the action
+func (a advanceAction) describe(task *Task) string {
+	var parts []string
+	if a.To != nil {
+		parts = append(parts, fmt.Sprintf("%s → %s", a.From.Title, a.To.Title))
+	}
+	if a.AddLabel != "" {
+		parts = append(parts, fmt.Sprintf("+%s", a.AddLabel))
+	}
+	return fmt.Sprintf("%s (%s): %s", truncateText(task.Title, 30), a.Reason, strings.Join(parts, " "))
+}
+
+// resolveRuleTarget turns a rule target into a column relative to from
+// Returns nil for "stay", unknown columns, or when there is no next/prev column
+func resolveRuleTarget(board *Board, from *Column, target, fallback string) *Column {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		target = fallback
+	}
+
+	index := -1
+	for i := range board.Columns {
+		if board.Columns[i].ID == from.ID {
+			index = i
+			break
+		}
+	}
+
+	switch strings.ToLower(target) {
+	case ruleTargetStay:
+		return nil
+	case ruleTargetNext:
+		if index >= 0 && index+1 < len(board.Columns) {
+			return &board.Columns[index+1]
+		}
+		return nil
+	case ruleTargetPrev:
+		if index > 0 {
+			return &board.Columns[index-1]
+		}
+		return nil
+	}
+
+	col := resolveColumn(board, target)
+	if col == nil || col.ID == from.ID {
+		return nil
+	}
+	return col
+}
+
+// evaluateAdvanceRule decides what happens to a task whose agent finished
+// with the given status, based on the rule of the column it's in
+func evaluateAdvanceRule(board *Board, task *Task, status AgentStatus) (advanceAction, bool) {
+	action := advanceAction{TaskID: task.ID}
+
+	from := findColumnByID(board, task.ColumnID)
+	if from == nil || from.AutoAdvance == nil {
+		return action, false
+	}
+	action.From = from
+	rule := from.AutoAdvance
+
+	switch status {
+	case AgentCompleted:
+		action.Reason = "agent completed"
+		action.To = resolveRuleTarget(board, from, rule.OnComplete, ruleTargetNext)
+	case AgentFailed:
+		action.Reason = "agent failed"
+		action.To = resolveRuleTarget(board, from, rule.OnFail, ruleTargetStay)
+		if rule.FailLabel != "" && !containsString(task.Labels, rule.FailLabel) {
+			action.AddLabel = rule.FailLabel
+		}
+	default:
+		return action, false
+	}
+
+	return action, !action.isNoop()
+}
+
+// containsString reports whether list contains s
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// addHistory appends an entry to a task's history, dropping the oldest past the cap
+func addHistory(task *Task, entry HistoryEntry) {
+	if entry.At.IsZero() {
+		entry.At = time.Now()
+	}
+	task.History = append(task.History, entry)
+	if len(task.History) > maxHistoryEntries {
+		task.History = task.History[len(task.History)-maxHistoryEntries:]
+	}
+}
+
+// applyAdvanceRules runs the auto-advance rule for a task whose agent finished
+// In dry-run mode the outcome is only reported in the status bar
+func (m *Model) applyAdvanceRules(task *Task, status AgentStatus) {
+	action, ok := evaluateAdvanceRule(m.board, task, status)
+	if !ok {
+		return
+	}
+
+	if m.rulesDryRun || m.board.RulesDryRun {
+		m.statusMessage = "Dry run, would apply: " + action.describe(task)
+		return
+	}
+
+	entry := HistoryEntry{Event: historyAutoAdvance, Detail: action.Reason}
+	if action.AddLabel != "" {
+		task.Labels = append(task.Labels, action.AddLabel)
+		entry.Detail += ", labeled " + action.AddLabel
+	}
+	if action.To != nil {
+		entry.From = action.From.Title
+		entry.To = action.To.Title
+		m.relocateTask(task, action.To.ID)
+	}
+	addHistory(task, entry)
+	task.UpdatedAt = time.Now()
+
+	if m.backend != nil {
+		err := m.backend.UpdateTask(task)
+		if err == nil && action.To != nil {
+			err = m.backend.MoveTask(task.ID, action.To.ID)
+		}
+		if err != nil {
+			m.statusMessage = "Auto-advance failed: " + err.Error()
+			m.reloadBoard()
+			return
+		}
+	}
+	m.statusMessage = "Auto-advanced " + action.describe(task)
+}
+
+// relocateTask moves a task to the end of another column in memory,
+// keeping the current selection in range
+func (m *Model) relocateTask(task *Task, toColumnID string) {
+	for i := range m.board.Columns {
+		col := &m.board.Columns[i]
+		for j, t := range col.Tasks {
+			if t == task {
+				col.Tasks = append(col.Tasks[:j], col.Tasks[j+1:]...)
+				break
+			}
+		}
+		if col.ID == toColumnID {
+			col.Tasks = append(col.Tasks, task)
+		}
+	}
+	task.ColumnID = toColumnID
+
+	if col := m.getCurrentColumn(); col != nil && m.selectedTask >= len(col.Tasks) {
+		m.selectedTask = len(col.Tasks) - 1
+		if m.selectedTask < 0 {
+			m.selectedTask = 0
+		}
+	}
+}
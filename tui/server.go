@@ -0,0 +1,555 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// server.go - Local HTTP/JSON API server mode
+// Exposes the Backend operations over a small REST API so the web UI,
+// editor plugins and agents can share one authoritative Go process
+
+// serverEvent is a server-sent event describing a board change
+// Mirrors the BeadsSyncEvent shape used by the web app's /api/beads/stream
+type serverEvent struct {
+	Type      string    `json:"type"` // connected, update, heartbeat, error
+	Timestamp time.Time `json:"timestamp"`
+	Board     *Board    `json:"board,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// createTaskRequest is the body of POST /api/tasks
+type createTaskRequest struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	ColumnID    string   `json:"columnId,omitempty"`
+	Type        string   `json:"type,omitempty"`
+	Priority    Priority `json:"priority"`
+}
+
+// moveTaskRequest is the body of POST /api/tasks/{id}/move
+type moveTaskRequest struct {
+	ColumnID string `json:"columnId"`
+}
+
+// taskPatch is a partial task update; nil fields are left unchanged
+type taskPatch struct {
+	Title       *string   `json:"title,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	Priority    *Priority `json:"priority,omitempty"`
+	Labels      *[]string `json:"labels,omitempty"`
+	Assignee    *string   `json:"assignee,omitempty"`
+	Estimate    *string   `json:"estimate,omitempty"`
+	DueDate     *string   `json:"dueDate,omitempty"`
+}
+
+// apply copies the set fields of the patch onto the task
+func (p taskPatch) apply(task *Task) {
+	if p.Title != nil {
+		task.Title = *p.Title
+	}
+	if p.Description != nil {
+		task.Description = *p.Description
+	}
+	if p.Priority != nil {
+		task.Priority = *p.Priority
+	}
+	if p.Labels != nil {
+		task.Labels = *p.Labels
+	}
+	if p.Assignee != nil {
+		task.Assignee = *p.Assignee
+	}
+	if p.Estimate != nil {
+		task.Estimate = *p.Estimate
+	}
+	if p.DueDate != nil {
+		task.DueDate = *p.DueDate
+	}
+}
+
+// Server serves a Backend over HTTP
+type Server struct {
+	backend Backend
+	mu      sync.Mutex // Serializes backend access (backends are not goroutine-safe)
+
+	// SSE subscribers and the digest of the last published board
+	subMu       sync.Mutex
+	subscribers map[chan serverEvent]struct{}
+	lastDigest  string
+
+	allowOrigin       string        // Value for Access-Control-Allow-Origin (empty disables CORS)
+	pollInterval      time.Duration // How often to check the backend for external changes
+	heartbeatInterval time.Duration // How often to send SSE heartbeats
+}
+
+// NewServer creates a new API server for the given backend
+func NewServer(backend Backend) *Server {
+	return &Server{
+		backend:           backend,
+		subscribers:       make(map[chan serverEvent]struct{}),
+		pollInterval:      2 * time.Second,
+		heartbeatInterval: 30 * time.Second,
+	}
+}
+
+// Handler returns the HTTP handler with all API routes registered
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/health", s.handleHealth)
+	mux.HandleFunc("GET /api/board", s.handleGetBoard)
+	mux.HandleFunc("GET /api/tasks", s.handleListTasks)
+	mux.HandleFunc("POST /api/tasks", s.handleCreateTask)
+	mux.HandleFunc("GET /api/tasks/{id}", s.handleGetTask)
+	mux.HandleFunc("PATCH /api/tasks/{id}", s.handleUpdateTask)
+	mux.HandleFunc("DELETE /api/tasks/{id}", s.handleDeleteTask)
+	mux.HandleFunc("POST /api/tasks/{id}/move", s.handleMoveTask)
+	mux.HandleFunc("GET /api/stream", s.handleStream)
+	return s.withCORS(mux)
+}
+
+// withCORS adds CORS headers for the configured origin and answers preflight requests
+func (s *Server) withCORS(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.allowOrigin != "" {
+			w.Header().Set("Access-Control-Allow-Origin", s.allowOrigin)
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PATCH, DELETE, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+			if r.Method == http.MethodOptions {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// loadBoard loads the board from the backend while holding the lock
+func (s *Server) loadBoard() (*Board, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.backend.LoadBoard()
+}
+
+// handleHealth reports which backend is being served
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	backendName := "local"
+	if _, ok := s.backend.(*BeadsBackend); ok {
+		backendName = "beads"
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"status":    "ok",
+		"backend":   backendName,
+		"timestamp": time.Now(),
+	})
+}
+
+// handleGetBoard returns the whole board (columns and tasks)
+func (s *Server) handleGetBoard(w http.ResponseWriter, r *http.Request) {
+	board, err := s.loadBoard()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, board)
+}
+
+// handleListTasks returns tasks, optionally filtered by ?column= and ?q=
+func (s *Server) handleListTasks(w http.ResponseWriter, r *http.Request) {
+	board, err := s.loadBoard()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	columnID := ""
+	if ref := r.URL.Query().Get("column"); ref != "" {
+		col := resolveColumn(board, ref)
+		if col == nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("unknown column %q", ref))
+			return
+		}
+		columnID = col.ID
+	}
+	query := strings.ToLower(r.URL.Query().Get("q"))
+
+	tasks := []*Task{}
+	for _, task := range board.Tasks {
+		if columnID != "" && task.ColumnID != columnID {
+			continue
+		}
+		if query != "" &&
+			!strings.Contains(strings.ToLower(task.Title), query) &&
+			!strings.Contains(strings.ToLower(task.Description), query) {
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+
+	writeJSON(w, http.StatusOK, tasks)
+}
+
+// handleGetTask returns a single task
+func (s *Server) handleGetTask(w http.ResponseWriter, r *http.Request) {
+	board, err := s.loadBoard()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	task := findTaskByID(board, r.PathValue("id"))
+	if task == nil {
+		writeError(w, http.StatusNotFound, fmt.Errorf("task %s not found", r.PathValue("id")))
+		return
+	}
+	writeJSON(w, http.StatusOK, task)
+}
+
+// handleCreateTask creates a task in the requested column (first column by default)
+func (s *Server) handleCreateTask(w http.ResponseWriter, r *http.Request) {
+	var req createTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if strings.TrimSpace(req.Title) == "" {
+		writeError(w, http.StatusBadRequest, errors.New("title is required"))
+		return
+	}
+
+	s.mu.Lock()
+	task, status, err := s.createTask(req)
+	s.mu.Unlock()
+	if err != nil {
+		writeError(w, status, err)
+		return
+	}
+
+	s.publishIfChanged()
+	writeJSON(w, http.StatusCreated, task)
+}
+
+// createTask resolves the target column and creates the task (caller holds the lock)
+func (s *Server) createTask(req createTaskRequest) (*Task, int, error) {
+	board, err := s.backend.LoadBoard()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if len(board.Columns) == 0 {
+		return nil, http.StatusConflict, errors.New("board has no columns")
+	}
+
+	col := &board.Columns[0]
+	if req.ColumnID != "" {
+		if col = resolveColumn(board, req.ColumnID); col == nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("unknown column %q", req.ColumnID)
+		}
+	}
+
+	task, err := s.backend.CreateTask(req.Title, req.Description, col.ID, req.Type, req.Priority)
+	if err != nil {
+		return nil, http.StatusBadGateway, err
+	}
+	return task, http.StatusCreated, nil
+}
+
+// handleUpdateTask applies a partial update to a task
+func (s *Server) handleUpdateTask(w http.ResponseWriter, r *http.Request) {
+	var patch taskPatch
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	s.mu.Lock()
+	task, status, err := s.updateTask(r.PathValue("id"), patch)
+	s.mu.Unlock()
+	if err != nil {
+		writeError(w, status, err)
+		return
+	}
+
+	s.publishIfChanged()
+	writeJSON(w, http.StatusOK, task)
+}
+
+// updateTask loads, patches and saves a task (caller holds the lock)
+func (s *Server) updateTask(taskID string, patch taskPatch) (*Task, int, error) {
+	board, err := s.backend.LoadBoard()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	task := findTaskByID(board, taskID)
+	if task == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("task %s not found", taskID)
+	}
+
+	patch.apply(task)
+	task.UpdatedAt = time.Now()
+	if err := s.backend.UpdateTask(task); err != nil {
+		return nil, http.StatusBadGateway, err
+	}
+	return task, http.StatusOK, nil
+}
+
+// handleMoveTask moves a task to another column
+func (s *Server) handleMoveTask(w http.ResponseWriter, r *http.Request) {
+	var req moveTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	s.mu.Lock()
+	task, status, err := s.moveTask(r.PathValue("id"), req.ColumnID)
+	s.mu.Unlock()
+	if err != nil {
+		writeError(w, status, err)
+		return
+	}
+
+	s.publishIfChanged()
+	writeJSON(w, http.StatusOK, task)
+}
+
+// moveTask validates the target column and moves the task (caller holds the lock)
+func (s *Server) moveTask(taskID, columnRef string) (*Task, int, error) {
+	board, err := s.backend.LoadBoard()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	task := findTaskByID(board, taskID)
+	if task == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("task %s not found", taskID)
+	}
+	col := resolveColumn(board, columnRef)
+	if col == nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("unknown column %q", columnRef)
+	}
+
+	if err := s.backend.MoveTask(task.ID, col.ID); err != nil {
+		return nil, http.StatusBadGateway, err
+	}
+	task.ColumnID = col.ID
+	task.UpdatedAt = time.Now()
+	return task, http.StatusOK, nil
+}
+
+// handleDeleteTask deletes (or, for beads, closes) a task
+func (s *Server) handleDeleteTask(w http.ResponseWriter, r *http.Request) {
+	taskID := r.PathValue("id")
+
+	s.mu.Lock()
+	board, err := s.backend.LoadBoard()
+	if err == nil && findTaskByID(board, taskID) == nil {
+		s.mu.Unlock()
+		writeError(w, http.StatusNotFound, fmt.Errorf("task %s not found", taskID))
+		return
+	}
+	if err == nil {
+		err = s.backend.DeleteTask(taskID)
+	}
+	s.mu.Unlock()
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	s.publishIfChanged()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleStream streams board changes as server-sent events
+// Clients receive a 'connected' event with the current board, an 'update'
+// event whenever the board changes, and a 'heartbeat' event periodically
+func (s *Server) handleStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errors.New("streaming not supported"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	events := s.subscribe()
+	defer s.unsubscribe(events)
+
+	board, err := s.loadBoard()
+	if err != nil {
+		writeSSE(w, serverEvent{Type: "error", Timestamp: time.Now(), Error: err.Error()})
+	} else {
+		writeSSE(w, serverEvent{Type: "connected", Timestamp: time.Now(), Board: board})
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(s.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event := <-events:
+			writeSSE(w, event)
+			flusher.Flush()
+		case <-heartbeat.C:
+			writeSSE(w, serverEvent{Type: "heartbeat", Timestamp: time.Now()})
+			flusher.Flush()
+		}
+	}
+}
+
+// subscribe registers a new SSE subscriber channel
+func (s *Server) subscribe() chan serverEvent {
+	ch := make(chan serverEvent, 16)
+	s.subMu.Lock()
+	s.subscribers[ch] = struct{}{}
+	s.subMu.Unlock()
+	return ch
+}
+
+// unsubscribe removes an SSE subscriber channel
+func (s *Server) unsubscribe(ch chan serverEvent) {
+	s.subMu.Lock()
+	delete(s.subscribers, ch)
+	s.subMu.Unlock()
+}
+
+// broadcast sends an event to every subscriber, dropping it for slow clients
+func (s *Server) broadcast(event serverEvent) {
+	s.subMu.Lock()
+	defer s.subMu.Unlock()
+	for ch := range s.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// publishIfChanged reloads the board and broadcasts an update if it differs
+// from the last published state
+func (s *Server) publishIfChanged() {
+	board, err := s.loadBoard()
+	if err != nil {
+		s.broadcast(serverEvent{Type: "error", Timestamp: time.Now(), Error: err.Error()})
+		return
+	}
+
+	digest := boardDigest(board)
+	s.subMu.Lock()
+	changed := digest != s.lastDigest
+	s.lastDigest = digest
+	s.subMu.Unlock()
+
+	if changed {
+		s.broadcast(serverEvent{Type: "update", Timestamp: time.Now(), Board: board})
+	}
+}
+
+// watch polls the backend for changes made outside this process
+func (s *Server) watch(ctx context.Context) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.publishIfChanged()
+		}
+	}
+}
+
+// boardDigest returns a hash of the board's columns and tasks
+// Board timestamps are excluded since beads regenerates them on every load
+func boardDigest(board *Board) string {
+	data, _ := json.Marshal(struct {
+		Columns []Column `json:"columns"`
+		Tasks   []*Task  `json:"tasks"`
+	}{board.Columns, board.Tasks})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// writeJSON writes a JSON response with the given status code
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes a JSON error response
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// writeSSE writes a single server-sent event
+func writeSSE(w http.ResponseWriter, event serverEvent) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "data: %s\n\n", data)
+}
+
+// runServe implements the `serve` subcommand
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	backendOpts := registerBackendFlags(fs)
+	addr := fs.String("addr", "127.0.0.1:4243", "Address to listen on")
+	allowOrigin := fs.String("cors-origin", "http://localhost:4242", "Origin allowed to call the API (empty to disable CORS)")
+	poll := fs.Duration("poll", 2*time.Second, "How often to check the board for external changes")
+	fs.Parse(args)
+
+	server := NewServer(backendOpts.openBackend())
+	server.allowOrigin = *allowOrigin
+	server.pollInterval = *poll
+
+	// Record the initial state so the first poll doesn't report a spurious update
+	if board, err := server.loadBoard(); err == nil {
+		server.lastDigest = boardDigest(board)
+	} else {
+		fmt.Fprintf(os.Stderr, "Error loading board: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go server.watch(ctx)
+
+	// Request contexts derive from ctx so open SSE streams end on shutdown
+	httpServer := &http.Server{
+		Addr:        *addr,
+		Handler:     server.Handler(),
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		httpServer.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Printf("Serving board API on http://%s\n", *addr)
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fmt.Fprintf(os.Stderr, "Error running server: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestServer serves a temp-dir board with one card in To Do
+func newTestServer(t *testing.T) (*httptest.Server, Backend) {
+	t.Helper()
+	backend := NewLocalBackend(filepath.Join(t.TempDir(), "board.yaml"))
+	board := &Board{ID: "board-1", Columns: []Column{{ID: "todo", Title: "To Do"}, {ID: "done", Title: "Done"}}}
+	board.Tasks = []*Task{{ID: "t-1", Title: "Fix login", ColumnID: "todo"}}
+	if err := backend.SaveBoard(board); err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(NewServer(backend).Handler())
+	t.Cleanup(ts.Close)
+	return ts, backend
+}
+
+// request sends a request and decodes the JSON response into out (when not nil)
+func request(t *testing.T, method, url, body string, out any) int {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("%s %s: %v", method, url, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestServerRoutes(t *testing.T) {
+	ts, backend := newTestServer(t)
+
+	var tasks []*Task
+	if status := request(t, "GET", ts.URL+"/api/tasks?column=To+Do", "", &tasks); status != http.StatusOK || len(tasks) != 1 {
+		t.Fatalf("list returned %d with %d tasks", status, len(tasks))
+	}
+
+	var created Task
+	status := request(t, "POST", ts.URL+"/api/tasks", `{"title":"Write docs","columnId":"todo","type":"feature"}`, &created)
+	if status != http.StatusCreated || created.Title != "Write docs" || created.ID == "" {
+		t.Fatalf("create returned %d: %+v", status, created)
+	}
+
+	var moved Task
+	if status := request(t, "POST", ts.URL+"/api/tasks/"+created.ID+"/move", `{"columnId":"Done"}`, &moved); status != http.StatusOK || moved.ColumnID != "done" {
+		t.Errorf("move returned %d: %+v", status, moved)
+	}
+	board, err := backend.LoadBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task := findTaskByID(board, created.ID); task == nil || task.ColumnID != "done" {
+		t.Errorf("created card saved as %+v", task)
+	}
+
+	var apiErr map[string]string
+	if status := request(t, "GET", ts.URL+"/api/tasks/t-404", "", &apiErr); status != http.StatusNotFound || apiErr["error"] == "" {
+		t.Errorf("unknown task returned %d %v", status, apiErr)
+	}
+	if status := request(t, "POST", ts.URL+"/api/tasks/t-404/move", `{"columnId":"done"}`, nil); status != http.StatusNotFound {
+		t.Errorf("moving an unknown task returned %d", status)
+	}
+	if status := request(t, "POST", ts.URL+"/api/tasks", `{"title":`, &apiErr); status != http.StatusBadRequest {
+		t.Errorf("bad JSON returned %d", status)
+	}
+}
+
+func TestServerStreamsUpdates(t *testing.T) {
+	ts, _ := newTestServer(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", ts.URL+"/api/stream", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	events := bufio.NewScanner(resp.Body)
+	events.Buffer(make([]byte, 64*1024), 1024*1024)
+	next := func() serverEvent {
+		t.Helper()
+		for events.Scan() {
+			if data, ok := strings.CutPrefix(events.Text(), "data: "); ok {
+				var event serverEvent
+				if err := json.Unmarshal([]byte(data), &event); err != nil {
+					t.Fatal(err)
+				}
+				return event
+			}
+		}
+		t.Fatalf("stream ended: %v", events.Err())
+		return serverEvent{}
+	}
+
+	if event := next(); event.Type != "connected" || len(event.Board.Tasks) != 1 {
+		t.Fatalf("first event %+v", event)
+	}
+	request(t, "POST", ts.URL+"/api/tasks", `{"title":"Write docs"}`, nil)
+	if event := next(); event.Type != "update" || len(event.Board.Tasks) != 2 {
+		t.Errorf("after a create got %+v", event)
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+)
+
+// sessions.go - Agent session continuity per task
+// Chats and headless runs started from a card are given a session ID that is
+// stored on the task, so the next chat resumes the same conversation
+
+// Session kinds
+const (
+	sessionKindChat = "chat" // Interactive chat (c)
+	sessionKindRun  = "run"  // Headless agent run (a)
+)
+
+// maxTaskSessions caps how many sessions are remembered per task
+const maxTaskSessions = 20
+
+// newSessionID returns a random UUID (v4), the format Claude expects for --session-id
+func newSessionID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40 // Version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// supportsSessionID reports whether we can choose and later resume an agent's
+// session ID from the command line
+func supportsSessionID(agentType AgentType) bool {
+	return agentType == AgentClaudeCode || agentType == ""
+}
+
+// latestSession returns the most recently used session for an agent type, or nil
+func latestSession(task *Task, agentType AgentType) *ChatSession {
+	var latest *ChatSession
+	for i := range task.Sessions {
+		s := &task.Sessions[i]
+		if s.Agent != agentType {
+			continue
+		}
+		if latest == nil || s.LastUsed.After(latest.LastUsed) {
+			latest = s
+		}
+	}
+	return latest
+}
+
+// recordSession adds a new session to the task. AgentInfo is left alone: it
+// describes the task's headless run, which may still be going
+func recordSession(task *Task, agentType AgentType, kind, column string) *ChatSession {
+	now := time.Now()
+	task.Sessions = append(task.Sessions, ChatSession{
+		ID:        newSessionID(),
+		Agent:     agentType,
+		Kind:      kind,
+		Column:    column,
+		StartedAt: now,
+		LastUsed:  now,
+	})
+	if len(task.Sessions) > maxTaskSessions {
+		task.Sessions = task.Sessions[len(task.Sessions)-maxTaskSessions:]
+	}
+
+	return &task.Sessions[len(task.Sessions)-1]
+}
+
+// removeSession drops a session from a task; returns false if the task
+// doesn't have it
+func removeSession(task *Task, id string) bool {
+	for i, session := range task.Sessions {
+		if session.ID == id {
+			task.Sessions = append(task.Sessions[:i], task.Sessions[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// liveRunSession reports whether a session belongs to the task's headless
+// agent run while it is still going (a chat can't resume it then)
+func liveRunSession(task *Task, id string) bool {
+	return task.Agent != nil && task.Agent.SessionID == id &&
+		(task.Agent.Status == AgentRunning || task.Agent.Status == AgentPaused)
+}
+
+// shortSessionID returns the first block of a session ID for display
+func shortSessionID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
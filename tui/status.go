@@ -0,0 +1,318 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// status.go - Status picker and defer prompt
+// s opens a status picker in the detail panel. On beads boards it lists every
+// built-in and custom status (a card lands in the column showing its status,
+// added if needed); on YAML boards the columns are the statuses. D defers a
+// beads issue, optionally until a date
+
+// statusOption is a row of the status picker
+type statusOption struct {
+	status   string // Beads status (beads boards)
+	columnID string // Column the card lands in, if it exists yet
+	label    string
+}
+
+// statusOptions lists the statuses a card can be given, in workflow order
+func (m Model) statusOptions() []statusOption {
+	beads, ok := m.backend.(*BeadsBackend)
+	if !ok {
+		options := make([]statusOption, len(m.board.Columns))
+		for i, col := range m.board.Columns {
+			options[i] = statusOption{columnID: col.ID, label: col.Title}
+		}
+		return options
+	}
+
+	// Custom statuses sit between in_progress and blocked
+	statuses := []string{StatusOpen, StatusInProgress}
+	statuses = append(statuses, beads.CustomStatuses()...)
+	for _, col := range m.board.Columns {
+		if col.Status != "" && !containsString(statuses, col.Status) && !containsString(beadsBuiltinStatuses, col.Status) {
+			statuses = append(statuses, col.Status)
+		}
+	}
+	statuses = append(statuses, StatusBlocked, StatusDeferred, StatusClosed)
+
+	options := make([]statusOption, len(statuses))
+	for i, status := range statuses {
+		options[i] = statusOption{status: status, label: status}
+		for _, col := range m.board.Columns {
+			if col.Status == status {
+				options[i].columnID = col.ID
+				break
+			}
+		}
+	}
+	return options
+}
+
+// taskStatus returns a beads task's status as last loaded or set, otherwise
+// the status its column shows
+func (m Model) taskStatus(task *Task) string {
+	if beads, ok := m.backend.(*BeadsBackend); ok && beads.statuses[task.ID] != "" {
+		return beads.statuses[task.ID]
+	}
+	if col := findColumnByID(m.board, task.ColumnID); col != nil {
+		return col.Status
+	}
+	return ""
+}
+
+// openStatusPicker shows the status picker for the selected task, opening
+// the detail panel for it
+func (m *Model) openStatusPicker() {
+	task := m.getCurrentTask()
+	if task == nil {
+		return
+	}
+
+	current := m.taskStatus(task)
+	m.statusPickerIndex = 0
+	for i, option := range m.statusOptions() {
+		if (option.status != "" && option.status == current) || (option.status == "" && option.columnID == task.ColumnID) {
+			m.statusPickerIndex = i
+			break
+		}
+	}
+
+	m.statusPickerActive = true
+	m.statusPickerPanel = m.showDetails
+	if !m.showDetails {
+		m.toggleDetails()
+	}
+}
+
+// closeStatusPicker hides the picker and the detail panel if it opened it
+func (m *Model) closeStatusPicker() {
+	m.statusPickerActive = false
+	if !m.statusPickerPanel && m.showDetails {
+		m.toggleDetails()
+	}
+}
+
+// chooseStatus applies the highlighted status to the selected task
+func (m *Model) chooseStatus() {
+	options := m.statusOptions()
+	task := m.getCurrentTask()
+	m.closeStatusPicker()
+	if task == nil || m.statusPickerIndex < 0 || m.statusPickerIndex >= len(options) {
+		return
+	}
+	option := options[m.statusPickerIndex]
+
+	if option.status == "" {
+		// YAML board: the status is the column
+		if m.promptResolution([]*Task{task}, option.columnID) {
+			return
+		}
+		failed, err := m.moveTasksTo([]*Task{task}, option.columnID)
+		if failed > 0 {
+			m.statusMessage = "Failed to move " + task.ID
+			return
+		}
+		if err != nil {
+			m.statusMessage = "Moved " + task.ID + saveFailure(err)
+		}
+		m.selectTask(task)
+		return
+	}
+
+	if option.status == StatusDeferred {
+		m.openDeferPrompt() // Ask for an optional date
+		return
+	}
+	if option.status == StatusClosed && m.promptResolution([]*Task{task}, statusColumn(m.board, StatusClosed).ID) {
+		return
+	}
+
+	beads := m.backend.(*BeadsBackend)
+	if err := beads.SetStatus(task.ID, option.status); err != nil {
+		m.statusMessage = err.Error()
+		return
+	}
+	m.placeByStatus(task, option.status)
+}
+
+// placeByStatus moves a card to the column showing its new status, adding
+// the column when no column shows it yet
+func (m *Model) placeByStatus(task *Task, status string) {
+	columns := len(m.board.Columns)
+	col := statusColumn(m.board, status)
+	if task.ColumnID != col.ID {
+		m.relocateTask(task, col.ID)
+	}
+	if len(m.board.Columns) != columns {
+		m.calculateLayout()
+	}
+
+	m.selectTask(task)
+	m.cachedIssueID = "" // Status changed, refetch details
+	m.fetchIssueDetails()
+	m.statusMessage = fmt.Sprintf("%s is now %s", task.ID, status)
+}
+
+// handleStatusPickerKeyMsg handles keyboard input while the status picker is open
+func (m Model) handleStatusPickerKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "s", "q":
+		m.closeStatusPicker()
+	case "up", "k":
+		if m.statusPickerIndex > 0 {
+			m.statusPickerIndex--
+		}
+	case "down", "j":
+		if m.statusPickerIndex < len(m.statusOptions())-1 {
+			m.statusPickerIndex++
+		}
+	case "enter":
+		m.chooseStatus()
+	}
+	return m, nil
+}
+
+// renderStatusSection renders the status and resolution lines of the detail
+// panel and, while it is open, the status picker below them
+func (m Model) renderStatusSection(task *Task, details *BeadsIssueDetails, contentWidth int) string {
+	var content strings.Builder
+
+	if m.isBeadsBackend() {
+		content.WriteString(styleDetailLabel.Render("Status: "))
+		content.WriteString(styleDetailValue.Render(m.taskStatus(task)))
+		if details != nil && details.DeferUntil != nil {
+			content.WriteString(styleSubdued.Render(" until " + details.DeferUntil.Format("2006-01-02")))
+		}
+		content.WriteString("\n\n")
+	}
+
+	resolution := task.Resolution
+	if resolution == "" && details != nil && details.ID == task.ID {
+		resolution = details.CloseReason
+	}
+	if resolution != "" {
+		content.WriteString(styleDetailLabel.Render("Resolution: "))
+		content.WriteString(styleDetailValue.Render(wrapText(sanitizeLine(resolution), contentWidth-12)))
+		content.WriteString("\n\n")
+	}
+
+	if !m.statusPickerActive {
+		return content.String()
+	}
+
+	content.WriteString(styleDetailLabel.Render("Set status:"))
+	content.WriteString("\n")
+	for i, option := range m.statusOptions() {
+		column := "(new column)"
+		if col := findColumnByID(m.board, option.columnID); col != nil {
+			column = col.Title
+		}
+		line := truncateText(option.label, 16)
+		if option.status != "" {
+			line = fmt.Sprintf("%-16s %s", line, column)
+		}
+		line = truncateText(line, contentWidth-2)
+		if i == m.statusPickerIndex {
+			content.WriteString(styleFormSelected.Render(line))
+		} else {
+			content.WriteString(styleFormOption.Render(line))
+		}
+		content.WriteString("\n")
+	}
+	content.WriteString(styleSubdued.Render("j/k: Select | Enter: Set | Esc: Cancel"))
+	content.WriteString("\n\n")
+
+	return content.String()
+}
+
+// openDeferPrompt asks until when the selected beads issue is deferred
+func (m *Model) openDeferPrompt() {
+	task := m.getCurrentTask()
+	if task == nil {
+		return
+	}
+	if !m.isBeadsBackend() {
+		m.statusMessage = "Deferring needs a beads board"
+		return
+	}
+
+	m.deferInput = textinput.New()
+	m.deferInput.Placeholder = "YYYY-MM-DD (empty: until reopened)"
+	m.deferInput.CharLimit = 10
+	m.deferInput.Width = 36
+	m.deferInput.Focus()
+	m.deferTaskID = task.ID
+	m.deferActive = true
+}
+
+// saveDeferPrompt defers the issue and moves its card to the deferred column
+func (m *Model) saveDeferPrompt() {
+	until := strings.TrimSpace(m.deferInput.Value())
+	if until != "" {
+		if _, err := time.Parse("2006-01-02", until); err != nil {
+			m.statusMessage = "Defer date must look like 2026-01-31"
+			return
+		}
+	}
+	m.deferActive = false
+
+	task := findTaskByID(m.board, m.deferTaskID)
+	beads, ok := m.backend.(*BeadsBackend)
+	if task == nil || !ok {
+		return
+	}
+	if err := beads.DeferTask(task.ID, until); err != nil {
+		m.statusMessage = err.Error()
+		return
+	}
+	m.placeByStatus(task, StatusDeferred)
+	if until != "" {
+		m.statusMessage = fmt.Sprintf("%s deferred until %s", task.ID, until)
+	}
+}
+
+// handleDeferKeyMsg handles keyboard input while the defer prompt is open
+func (m Model) handleDeferKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.deferActive = false
+		return m, nil
+	case "enter":
+		m.saveDeferPrompt()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.deferInput, cmd = m.deferInput.Update(msg)
+	return m, cmd
+}
+
+// renderDeferPrompt renders the defer prompt overlay
+func (m Model) renderDeferPrompt(background string) string {
+	var content strings.Builder
+	content.WriteString(styleDetailTitle.Render("Defer " + m.deferTaskID))
+	content.WriteString("\n\n")
+	content.WriteString(styleDetailLabel.Render("Until:"))
+	content.WriteString("\n")
+	content.WriteString(m.deferInput.View())
+	content.WriteString("\n\n")
+	content.WriteString(styleSubdued.Render("Enter: Defer | Esc: Cancel"))
+
+	overlay := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorPrimary).
+		Padding(1, 2).
+		Width(48).
+		Render(content.String())
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay)
+}
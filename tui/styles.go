@@ -56,6 +56,8 @@ var (
 	colorSelected  = lipgloss.Color("212") // Pink/magenta
 	colorSubdued   = lipgloss.Color("243") // Subdued gray
 	colorHighlight = lipgloss.Color("229") // Bright yellow
+	colorOnAccent  = lipgloss.Color("0")   // Text on primary/priority backgrounds
+	colorLabel     = lipgloss.Color("238") // Label chip background
 
 	// Priority colors
 	colorPriorityLow    = lipgloss.Color("243") // Slate
@@ -103,136 +105,173 @@ func GetAgentStatusColor(s AgentStatus) lipgloss.Color {
 	return colorAgentIdle
 }
 
-// Base styles
+// Styles are built from the colors above by buildStyles, and rebuilt when
+// a theme is applied
 var (
+	styleTitle                lipgloss.Style
+	styleStatus               lipgloss.Style
+	styleSubdued              lipgloss.Style
+	stylePanelBorder          lipgloss.Style
+	styleDivider              lipgloss.Style
+	styleColumnHeader         lipgloss.Style
+	styleColumnHeaderSelected lipgloss.Style
+	styleCard                 lipgloss.Style
+	styleCardSelected         lipgloss.Style
+	styleCardMarked           lipgloss.Style
+	styleDropIndicator        lipgloss.Style
+	styleCardGhost            lipgloss.Style
+	styleCardContent          lipgloss.Style
+	styleDetailPanel          lipgloss.Style
+	styleDetailTitle          lipgloss.Style
+	styleDetailLabel          lipgloss.Style
+	styleDetailValue          lipgloss.Style
+	styleLabel                lipgloss.Style
+	styleEpicTag              lipgloss.Style
+	stylePriorityBadge        lipgloss.Style
+	styleAgentBadge           lipgloss.Style
+	styleFormSelected         lipgloss.Style
+	styleFormOption           lipgloss.Style
+)
+
+// Card size (12 chars wide x 5 lines tall - Solitaire-style)
+var (
+	cardWidth  = 14
+	cardHeight = 5
+)
+
+func init() {
+	buildStyles()
+}
+
+// buildStyles (re)creates every style from the current colors
+func buildStyles() {
 	// Title bar style (with terminal glow effect)
 	styleTitle = lipgloss.NewStyle().
-			Foreground(colorTitle).
-			Bold(true).
-			Padding(0, 1)
+		Foreground(colorTitle).
+		Bold(true).
+		Padding(0, 1)
 
 	// Status bar style
 	styleStatus = lipgloss.NewStyle().
-			Foreground(colorSubdued).
-			Padding(0, 1)
+		Foreground(colorSubdued).
+		Padding(0, 1)
 
 	// Subdued text style (for secondary info)
 	styleSubdued = lipgloss.NewStyle().
-			Foreground(colorSubdued)
+		Foreground(colorSubdued)
 
 	// Panel border style
 	stylePanelBorder = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(colorBorder).
-				Padding(0, 1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorBorder).
+		Padding(0, 1)
 
 	// Divider style (vertical line between panels)
 	styleDivider = lipgloss.NewStyle().
-			Foreground(colorDivider)
-)
+		Foreground(colorDivider)
 
-// Column styles
-var (
 	// Column header style (centered, bold)
 	styleColumnHeader = lipgloss.NewStyle().
-				Foreground(colorPrimary).
-				Bold(true).
-				Align(lipgloss.Center)
+		Foreground(colorPrimary).
+		Bold(true).
+		Align(lipgloss.Center)
 
 	// Selected column header style
 	styleColumnHeaderSelected = lipgloss.NewStyle().
-					Foreground(colorSelected).
-					Bold(true).
-					Align(lipgloss.Center)
-)
-
-// Card/Task styles (12 chars wide x 5 lines tall - Solitaire-style)
-var (
-	cardWidth  = 14
-	cardHeight = 5
+		Foreground(colorSelected).
+		Bold(true).
+		Align(lipgloss.Center)
 
 	// Normal card style
 	styleCard = lipgloss.NewStyle().
-			Width(cardWidth).
-			Height(cardHeight).
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(colorBorder).
-			Padding(0, 1)
+		Width(cardWidth).
+		Height(cardHeight).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorBorder).
+		Padding(0, 1)
 
 	// Selected card style
 	styleCardSelected = lipgloss.NewStyle().
-				Width(cardWidth).
-				Height(cardHeight).
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(colorSelected).
-				Foreground(colorSelected).
-				Bold(true).
-				Padding(0, 1)
+		Width(cardWidth).
+		Height(cardHeight).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorSelected).
+		Foreground(colorSelected).
+		Bold(true).
+		Padding(0, 1)
+
+	// Marked (multi-selected) card style
+	styleCardMarked = lipgloss.NewStyle().
+		Width(cardWidth).
+		Height(cardHeight).
+		Border(lipgloss.ThickBorder()).
+		BorderForeground(colorWarning).
+		Padding(0, 1)
 
 	// Drop indicator style (thin line showing where task will be dropped)
 	styleDropIndicator = lipgloss.NewStyle().
-				Foreground(colorSuccess).
-				Bold(true)
+		Foreground(colorSuccess).
+		Bold(true)
 
 	// Ghost card style (for card being dragged)
 	styleCardGhost = lipgloss.NewStyle().
-			Width(cardWidth).
-			Height(cardHeight).
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(colorSubdued).
-			Foreground(colorSubdued).
-			Padding(0, 1)
+		Width(cardWidth).
+		Height(cardHeight).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorSubdued).
+		Foreground(colorSubdued).
+		Padding(0, 1)
 
 	// Card content style (for text inside cards)
 	styleCardContent = lipgloss.NewStyle().
-				Width(cardWidth - 2). // Account for padding
-				Foreground(colorForeground)
-)
+		Width(cardWidth - 2). // Account for padding
+		Foreground(colorForeground)
 
-// Detail panel styles
-var (
+	// Detail panel styles
 	styleDetailPanel = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(colorBorder).
-				Padding(1, 2)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorBorder).
+		Padding(1, 2)
 
 	styleDetailTitle = lipgloss.NewStyle().
-				Foreground(colorTitle).
-				Bold(true).
-				Underline(true)
+		Foreground(colorTitle).
+		Bold(true).
+		Underline(true)
 
 	styleDetailLabel = lipgloss.NewStyle().
-				Foreground(colorSubdued).
-				Bold(true)
+		Foreground(colorSubdued).
+		Bold(true)
 
 	styleDetailValue = lipgloss.NewStyle().
-				Foreground(colorForeground)
+		Foreground(colorForeground)
 
 	styleLabel = lipgloss.NewStyle().
-			Foreground(colorInfo).
-			Background(lipgloss.Color("238")).
-			Padding(0, 1).
-			MarginRight(1)
+		Foreground(colorInfo).
+		Background(colorLabel).
+		Padding(0, 1).
+		MarginRight(1)
+
+	styleEpicTag = lipgloss.NewStyle().
+		Foreground(colorSecondary).
+		Bold(true)
 
 	stylePriorityBadge = lipgloss.NewStyle().
-				Padding(0, 1)
+		Padding(0, 1)
 
 	styleAgentBadge = lipgloss.NewStyle().
-			Padding(0, 1)
-)
+		Padding(0, 1)
 
-// Form styles for quick-add modal
-var (
+	// Form styles for quick-add modal
 	styleFormSelected = lipgloss.NewStyle().
-				Background(colorPrimary).
-				Foreground(lipgloss.Color("0")).
-				Bold(true).
-				Padding(0, 1)
+		Background(colorPrimary).
+		Foreground(colorOnAccent).
+		Bold(true).
+		Padding(0, 1)
 
 	styleFormOption = lipgloss.NewStyle().
-			Foreground(colorSubdued).
-			Padding(0, 1)
-)
+		Foreground(colorSubdued).
+		Padding(0, 1)
+}
 
 // Helper functions for styling
 
@@ -277,32 +316,42 @@ func renderCompactAgentBadge(agent *AgentInfo) string {
 	}
 }
 
-// renderCardBadgeLine renders the first line with priority and agent badges
+// renderCardBadgeLine renders the first line with priority, epic and agent badges
 func renderCardBadgeLine(task *Task, maxWidth int) string {
-	priority := renderCompactPriorityBadge(task.Priority)
-	agent := renderCompactAgentBadge(task.Agent)
-
-	if agent != "" {
-		return priority + " " + agent
+	line := renderCompactPriorityBadge(task.Priority)
+	if isEpic(task) {
+		line += " " + renderEpicTag()
+	}
+	if agent := renderCompactAgentBadge(task.Agent); agent != "" {
+		line += " " + agent
+	}
+	if chips := renderCardChips(task, maxWidth-lipgloss.Width(line)-1); chips != "" {
+		line += " " + chips
 	}
-	return priority
+	return line
 }
 
 // renderCard renders a card with the given task (with badges)
-func renderCard(task *Task, selected bool) string {
-	return renderCardWithStyle(task, selected, false)
+// progress is the epic's child progress (zero for other tasks)
+func renderCard(task *Task, selected, marked bool, progress childProgress) string {
+	return renderCardWithStyle(task, selected, marked, false, progress)
 }
 
 // renderCardGhost renders a faded ghost card (for dragging)
-func renderCardGhost(task *Task) string {
-	return renderCardWithStyle(task, false, true)
+func renderCardGhost(task *Task, progress childProgress) string {
+	return renderCardWithStyle(task, false, false, true, progress)
 }
 
 // renderCardWithStyle renders a card with the given task and style options
-func renderCardWithStyle(task *Task, selected bool, ghost bool) string {
+// A marked card keeps its thick border when selected so the selection stays visible
+func renderCardWithStyle(task *Task, selected, marked, ghost bool, progress childProgress) string {
 	style := styleCard
 	if ghost {
 		style = styleCardGhost
+	} else if marked && selected {
+		style = styleCardMarked.BorderForeground(colorSelected).Foreground(colorSelected).Bold(true)
+	} else if marked {
+		style = styleCardMarked
 	} else if selected {
 		style = styleCardSelected
 	}
@@ -316,6 +365,15 @@ func renderCardWithStyle(task *Task, selected bool, ghost bool) string {
 
 	// Wrap title to fit remaining card space (3 lines max - 1 for badges = 2 for title)
 	wrappedTitle := wrapCardTitle(task.Title, maxWidth)
+
+	// Epics with children give their last line to the progress bar
+	if progress.total > 0 {
+		lines := strings.Split(wrappedTitle, "\n")
+		if len(lines) > 2 {
+			lines = lines[:2]
+		}
+		wrappedTitle = strings.Join(lines, "\n") + "\n" + renderProgressBar(progress, maxWidth, ghost)
+	}
 	content.WriteString(wrappedTitle)
 
 	return style.Render(content.String())
@@ -323,9 +381,9 @@ func renderCardWithStyle(task *Task, selected bool, ghost bool) string {
 
 // renderCardTopLines renders just the top 2 lines of a card (for stacking)
 // This creates the Solitaire-style cascading effect
-func renderCardTopLines(task *Task, selected bool) string {
+func renderCardTopLines(task *Task, selected, marked bool, progress childProgress) string {
 	// Render full card first
-	fullCard := renderCardWithStyle(task, selected, false)
+	fullCard := renderCardWithStyle(task, selected, marked, false, progress)
 
 	// Extract just the top 2 lines
 	lines := strings.Split(fullCard, "\n")
@@ -336,9 +394,9 @@ func renderCardTopLines(task *Task, selected bool) string {
 }
 
 // renderCardTopLinesGhost renders just the top 2 lines of a ghost card
-func renderCardTopLinesGhost(task *Task) string {
+func renderCardTopLinesGhost(task *Task, progress childProgress) string {
 	// Render full ghost card first
-	fullCard := renderCardGhost(task)
+	fullCard := renderCardGhost(task, progress)
 
 	// Extract just the top 2 lines
 	lines := strings.Split(fullCard, "\n")
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"embed"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// templates.go - Board templates for `init` and the startup template picker
+// Built-in templates mirror the web app's BOARD_TEMPLATES and are embedded as
+// YAML; users can add their own in <config dir>/ai-kanban-board/templates
+
+//go:embed templates/*.yaml
+var builtinTemplateFS embed.FS
+
+// BoardTemplate describes a board layout that new boards can be created from
+type BoardTemplate struct {
+	Key         string   `yaml:"-"` // File name without extension, used by --template
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description"`
+	Order       int      `yaml:"order"`
+	Columns     []Column `yaml:"columns"`
+	Source      string   `yaml:"-"` // "built-in" or the template file path
+}
+
+// userConfigDir returns the app's directory under the XDG config dir
+func userConfigDir() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "ai-kanban-board"), nil
+}
+
+// parseTemplate parses a template YAML file
+func parseTemplate(key string, data []byte, source string) (BoardTemplate, error) {
+	var tmpl BoardTemplate
+	if err := yaml.Unmarshal(data, &tmpl); err != nil {
+		return tmpl, fmt.Errorf("failed to parse template %s: %w", source, err)
+	}
+	if len(tmpl.Columns) == 0 {
+		return tmpl, fmt.Errorf("template %s has no columns", source)
+	}
+	tmpl.Key = key
+	tmpl.Source = source
+	if tmpl.Name == "" {
+		tmpl.Name = key
+	}
+	return tmpl, nil
+}
+
+// loadTemplates returns the built-in templates followed by the user's own
+// A user template with the same key as a built-in replaces it
+func loadTemplates() []BoardTemplate {
+	byKey := make(map[string]BoardTemplate)
+
+	files, _ := builtinTemplateFS.ReadDir("templates")
+	for _, f := range files {
+		data, err := builtinTemplateFS.ReadFile("templates/" + f.Name())
+		if err != nil {
+			continue
+		}
+		key := strings.TrimSuffix(f.Name(), filepath.Ext(f.Name()))
+		if tmpl, err := parseTemplate(key, data, "built-in"); err == nil {
+			byKey[key] = tmpl
+		}
+	}
+
+	if dir, err := userConfigDir(); err == nil {
+		userFiles, _ := os.ReadDir(filepath.Join(dir, "templates"))
+		for _, f := range userFiles {
+			ext := filepath.Ext(f.Name())
+			if f.IsDir() || (ext != ".yaml" && ext != ".yml") {
+				continue
+			}
+			path := filepath.Join(dir, "templates", f.Name())
+			data, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			key := strings.TrimSuffix(f.Name(), ext)
+			tmpl, err := parseTemplate(key, data, path)
+			if err != nil {
+				continue
+			}
+			if _, isBuiltin := byKey[key]; !isBuiltin && tmpl.Order == 0 {
+				tmpl.Order = 1000 // User templates sort after the built-ins
+			}
+			byKey[key] = tmpl
+		}
+	}
+
+	templates := make([]BoardTemplate, 0, len(byKey))
+	for _, tmpl := range byKey {
+		templates = append(templates, tmpl)
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		if templates[i].Order != templates[j].Order {
+			return templates[i].Order < templates[j].Order
+		}
+		return templates[i].Key < templates[j].Key
+	})
+	return templates
+}
+
+// findTemplate looks up a template by key or case-insensitive name
+func findTemplate(templates []BoardTemplate, ref string) (BoardTemplate, bool) {
+	for _, tmpl := range templates {
+		if tmpl.Key == ref || strings.EqualFold(tmpl.Name, ref) {
+			return tmpl, true
+		}
+	}
+	return BoardTemplate{}, false
+}
+
+// NewBoard creates an empty board with the template's columns
+func (t BoardTemplate) NewBoard() *Board {
+	now := time.Now()
+	board := &Board{
+		ID:          "board-1",
+		Name:        t.Name,
+		Description: t.Description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Tasks:       []*Task{},
+	}
+
+	for i, col := range t.Columns {
+		col.ID = fmt.Sprintf("col-%d", i+1)
+		col.Order = i
+		col.Tasks = nil
+		if col.Color == "" {
+			col.Color = "border-t-slate-500"
+		}
+		board.Columns = append(board.Columns, col)
+	}
+
+	return board
+}
+
+// agentStepCount returns how many columns have an assigned agent
+func (t BoardTemplate) agentStepCount() int {
+	count := 0
+	for _, col := range t.Columns {
+		if col.AssignedAgent != "" {
+			count++
+		}
+	}
+	return count
+}
+
+// runInit implements the `init` subcommand
+func runInit(args []string) {
+	fs := flag.NewFlagSet("init", flag.ExitOnError)
+	boardFile := fs.String("board", "board.yaml", "Path of the board file to create")
+	templateName := fs.String("template", "standard", "Template to create the board from")
+	list := fs.Bool("list", false, "List available templates")
+	force := fs.Bool("force", false, "Overwrite an existing board file")
+	fs.Parse(args)
+
+	templates := loadTemplates()
+
+	if *list {
+		for _, tmpl := range templates {
+			fmt.Printf("  %-16s %-16s %2d steps, %d AI  %s\n",
+				tmpl.Key, tmpl.Name, len(tmpl.Columns), tmpl.agentStepCount(), tmpl.Description)
+		}
+		return
+	}
+
+	tmpl, ok := findTemplate(templates, *templateName)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown template %q (see init --list)\n", *templateName)
+		os.Exit(1)
+	}
+
+	if _, err := os.Stat(*boardFile); err == nil && !*force {
+		fmt.Fprintf(os.Stderr, "%s already exists (use --force to overwrite)\n", *boardFile)
+		os.Exit(1)
+	}
+
+	if err := NewLocalBackend(*boardFile).SaveBoard(tmpl.NewBoard()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing board: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Created %s from the %s template\n", *boardFile, tmpl.Name)
+}
+
+// openTemplatePicker shows the template picker (used when the board file is missing)
+func (m *Model) openTemplatePicker() {
+	m.templateChoices = loadTemplates()
+	m.templateIndex = 0
+	m.templatePickerActive = len(m.templateChoices) > 0
+}
+
+// applyTemplate creates and saves a board from the highlighted template
+func (m *Model) applyTemplate() {
+	if m.templateIndex < 0 || m.templateIndex >= len(m.templateChoices) {
+		return
+	}
+
+	tmpl := m.templateChoices[m.templateIndex]
+	board := tmpl.NewBoard()
+	if m.backend != nil {
+		if err := m.backend.SaveBoard(board); err != nil {
+			m.statusMessage = fmt.Sprintf("Couldn't save the %s board: %v", tmpl.Name, err)
+			return
+		}
+	}
+	m.board = board
+	m.selectedColumn = 0
+	m.selectedTask = 0
+	m.columnScrollOffset = make(map[int]int)
+	m.calculateLayout()
+}
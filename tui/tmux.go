@@ -2,100 +2,90 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
 	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
 )
 
-// tmux.go - Tmux Integration for floating chat popup
-// Launches Claude in a tmux popup with card context
+// tmux.go - Tmux launchers for the chat command
+// Opens the agent chat in a floating popup or a new window with card context
+
+// defaultPopupSize is the tmux popup geometry when --popup-size isn't given
+const defaultPopupSize = "80%"
 
 // isInsideTmux checks if the program is running inside a tmux session
 func isInsideTmux() bool {
-	return exec.Command("sh", "-c", "[ -n \"$TMUX\" ]").Run() == nil
+	return os.Getenv("TMUX") != ""
 }
 
-// launchChatPopup launches Claude in a tmux popup with task context
-// Returns error if not in tmux or if popup fails
-func launchChatPopup(task *Task, issueDetails *BeadsIssueDetails) error {
-	if !isInsideTmux() {
-		return fmt.Errorf("not running inside tmux - popup requires tmux")
+// parsePopupSize parses "80%" (both dimensions) or "WxH" such as "120x40" or "90%x70%"
+func parsePopupSize(size string) (width, height string) {
+	size = strings.TrimSpace(size)
+	if size == "" {
+		size = defaultPopupSize
 	}
-
-	if task == nil {
-		return fmt.Errorf("no task selected")
+	if w, h, ok := strings.Cut(size, "x"); ok {
+		return w, h
 	}
+	return size, size
+}
 
-	// Build context string for Claude
-	var contextParts []string
-
-	// Add issue ID if it looks like a beads ID
-	if strings.HasPrefix(task.ID, "ai-kanban-board-") {
-		contextParts = append(contextParts, fmt.Sprintf("Issue: %s", task.ID))
-	}
+// tmuxPopupLauncher opens the chat in a tmux popup over the TUI
+type tmuxPopupLauncher struct {
+	width, height string
+}
 
-	// Add title
-	contextParts = append(contextParts, fmt.Sprintf("Title: %s", task.Title))
+func (tmuxPopupLauncher) Name() string { return LauncherTmuxPopup }
 
-	// Add description if present
-	if task.Description != "" {
-		contextParts = append(contextParts, fmt.Sprintf("Description: %s", task.Description))
+func (l tmuxPopupLauncher) Launch(spec LaunchSpec) tea.Cmd {
+	if !isInsideTmux() {
+		return launchError(spec, fmt.Errorf("not running inside tmux - popup requires tmux"))
 	}
 
-	// Add priority
-	contextParts = append(contextParts, fmt.Sprintf("Priority: %s", task.Priority.String()))
-
-	// Add labels/type
-	if len(task.Labels) > 0 {
-		contextParts = append(contextParts, fmt.Sprintf("Type: %s", task.Labels[0]))
+	argv, specFile, err := handoffArgv(spec)
+	if err != nil {
+		return launchError(spec, err)
 	}
 
-	// Add dependencies if from beads
-	if issueDetails != nil {
-		if len(issueDetails.Dependencies) > 0 {
-			var depIDs []string
-			for _, dep := range issueDetails.Dependencies {
-				depIDs = append(depIDs, dep.ID)
-			}
-			contextParts = append(contextParts, fmt.Sprintf("Blocked by: %s", strings.Join(depIDs, ", ")))
-		}
-		if len(issueDetails.Dependents) > 0 {
-			var depIDs []string
-			for _, dep := range issueDetails.Dependents {
-				depIDs = append(depIDs, dep.ID)
-			}
-			contextParts = append(contextParts, fmt.Sprintf("Blocks: %s", strings.Join(depIDs, ", ")))
-		}
+	// -d and -T are tmux formats, so escape anything taken from the task
+	dir := "#{pane_current_path}"
+	if spec.Dir != "" {
+		dir = escapeTmuxFormat(spec.Dir)
 	}
 
-	context := strings.Join(contextParts, "\n")
+	// -E: close popup when command exits
+	// -d: start in the task's worktree or the current pane's directory
+	args := []string{"display-popup", "-E",
+		"-w", l.width,
+		"-h", l.height,
+		"-d", dir,
+		"-T", escapeTmuxFormat(spec.Title),
+	}
+	args = append(args, argv...)
+	return runExternal(spec, exec.Command("tmux", args...), specFile)
+}
 
-	// Build the initial prompt for Claude
-	prompt := fmt.Sprintf("I'm working on this task from my kanban board:\n\n%s\n\nHelp me with this task.", context)
+// tmuxWindowLauncher opens the chat in a new tmux window named after the task
+type tmuxWindowLauncher struct{}
 
-	// Escape the prompt for shell (replace single quotes)
-	escapedPrompt := strings.ReplaceAll(prompt, "'", "'\"'\"'")
+func (tmuxWindowLauncher) Name() string { return LauncherTmuxWindow }
 
-	// Build the claude command with the prompt
-	// Use -p to pass an initial prompt to Claude
-	claudeCmd := fmt.Sprintf("claude -p '%s'", escapedPrompt)
+func (tmuxWindowLauncher) Launch(spec LaunchSpec) tea.Cmd {
+	if !isInsideTmux() {
+		return launchError(spec, fmt.Errorf("not running inside tmux - new-window requires tmux"))
+	}
 
-	// Launch Claude in a tmux popup
-	// -E: close popup when command exits
-	// -w 80%%: 80% width
-	// -h 80%%: 80% height
-	// -d: start in current pane's directory
-	cmd := exec.Command("tmux", "display-popup",
-		"-E",
-		"-w", "80%",
-		"-h", "80%",
-		"-d", "#{pane_current_path}",
-		"sh", "-c", claudeCmd,
-	)
-
-	return cmd.Run()
-}
+	argv, specFile, err := handoffArgv(spec)
+	if err != nil {
+		return launchError(spec, err)
+	}
 
-// launchChatPopupSimple launches Claude with just task context (no beads details)
-func launchChatPopupSimple(task *Task) error {
-	return launchChatPopup(task, nil)
+	args := []string{"new-window", "-n", escapeTmuxFormat(spec.Title)}
+	if spec.Dir != "" {
+		args = append(args, "-c", escapeTmuxFormat(spec.Dir))
+	}
+	args = append(args, argv...)
+	return runExternal(spec, exec.Command("tmux", args...), specFile)
 }
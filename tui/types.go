@@ -3,6 +3,7 @@ package main
 import (
 	"time"
 
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 )
 
@@ -80,6 +81,53 @@ type Task struct {
 
 	// Git integration
 	Git *GitInfo `yaml:"git,omitempty" json:"git,omitempty"`
+
+	// Parent epic (task ID)
+	Parent string `yaml:"parent,omitempty" json:"parentId,omitempty"`
+
+	// Where the task was imported from, e.g. "github:https://github.com/o/r/issues/7" (beads external_ref)
+	ExternalID string `yaml:"external_id,omitempty" json:"externalId,omitempty"`
+
+	// How the task was closed: "won't fix: ...", "duplicate of kb-3" (beads close_reason)
+	Resolution string `yaml:"resolution,omitempty" json:"resolution,omitempty"`
+
+	// Closed children the backend didn't load (beads hides closed issues)
+	HiddenChildren int `yaml:"-" json:"-"`
+
+	// Automatic changes made to the card (auto-advance moves etc.)
+	History []HistoryEntry `yaml:"history,omitempty" json:"history,omitempty"`
+
+	// Agent conversations started from this card, oldest first
+	Sessions []ChatSession `yaml:"sessions,omitempty" json:"sessions,omitempty"`
+
+	// Discussion thread, oldest first (beads keeps its own, see comments.go)
+	Comments []Comment `yaml:"comments,omitempty" json:"comments,omitempty"`
+}
+
+// Comment is a note in a task's discussion thread
+type Comment struct {
+	Author    string    `yaml:"author,omitempty" json:"author,omitempty"`
+	Text      string    `yaml:"text" json:"text"`
+	CreatedAt time.Time `yaml:"created_at" json:"createdAt"`
+}
+
+// ChatSession records an agent conversation started for a task so it can be resumed
+type ChatSession struct {
+	ID        string    `yaml:"id" json:"id"`
+	Agent     AgentType `yaml:"agent" json:"agent"`
+	Kind      string    `yaml:"kind" json:"kind"`                         // "chat" (c) or "run" (a)
+	Column    string    `yaml:"column,omitempty" json:"column,omitempty"` // Column title when started
+	StartedAt time.Time `yaml:"started_at" json:"startedAt"`
+	LastUsed  time.Time `yaml:"last_used" json:"lastUsed"`
+}
+
+// HistoryEntry records something that happened to a task
+type HistoryEntry struct {
+	At     time.Time `yaml:"at" json:"at"`
+	Event  string    `yaml:"event" json:"event"` // e.g. "auto-advance"
+	From   string    `yaml:"from,omitempty" json:"from,omitempty"`
+	To     string    `yaml:"to,omitempty" json:"to,omitempty"`
+	Detail string    `yaml:"detail,omitempty" json:"detail,omitempty"`
 }
 
 // AgentInfo represents AI agent state for a task
@@ -109,21 +157,36 @@ type Column struct {
 	Order       int     `yaml:"order" json:"order"`
 	WIPLimit    int     `yaml:"wip_limit,omitempty" json:"wipLimit,omitempty"`
 	IsCollapsed bool    `yaml:"is_collapsed,omitempty" json:"isCollapsed,omitempty"`
-	Tasks       []*Task `yaml:"-" json:"-"` // Populated at runtime from Board.Tasks
+	Status      string  `yaml:"status,omitempty" json:"status,omitempty"` // Beads status of cards in this column (beads boards)
+	Tasks       []*Task `yaml:"-" json:"-"`                               // Populated at runtime from Board.Tasks
 
 	// Agent configuration for this column
 	AssignedAgent AgentType `yaml:"assigned_agent,omitempty" json:"assignedAgent,omitempty"`
+	Prompt        string    `yaml:"prompt,omitempty" json:"prompt,omitempty"` // Step prompt template, see prompts.go
+
+	// Where cards go when their agent finishes, see rules.go
+	AutoAdvance *AdvanceRule `yaml:"auto_advance,omitempty" json:"autoAdvance,omitempty"`
+}
+
+// AdvanceRule moves a card when the agent working on it finishes
+// Targets are "next", "prev", "stay" or a column ID/title
+type AdvanceRule struct {
+	OnComplete string `yaml:"on_complete,omitempty" json:"onComplete,omitempty"` // Default "next"
+	OnFail     string `yaml:"on_fail,omitempty" json:"onFail,omitempty"`         // Default "stay"
+	FailLabel  string `yaml:"fail_label,omitempty" json:"failLabel,omitempty"`   // Label added on failure
 }
 
 // Board represents the entire Kanban board
 type Board struct {
-	ID          string    `yaml:"id" json:"id"`
-	Name        string    `yaml:"name" json:"name"`
-	Description string    `yaml:"description,omitempty" json:"description,omitempty"`
-	Columns     []Column  `yaml:"columns" json:"columns"`
-	Tasks       []*Task   `yaml:"tasks" json:"tasks"`
-	CreatedAt   time.Time `yaml:"created_at" json:"createdAt"`
-	UpdatedAt   time.Time `yaml:"updated_at" json:"updatedAt"`
+	ID          string         `yaml:"id" json:"id"`
+	Name        string         `yaml:"name" json:"name"`
+	Description string         `yaml:"description,omitempty" json:"description,omitempty"`
+	Columns     []Column       `yaml:"columns" json:"columns"`
+	Tasks       []*Task        `yaml:"tasks" json:"tasks"`
+	RulesDryRun bool           `yaml:"rules_dry_run,omitempty" json:"rulesDryRun,omitempty"` // Report auto-advance moves without making them
+	Context     *ContextConfig `yaml:"context,omitempty" json:"context,omitempty"`           // Task context handed to agents, see handoff.go
+	CreatedAt   time.Time      `yaml:"created_at" json:"createdAt"`
+	UpdatedAt   time.Time      `yaml:"updated_at" json:"updatedAt"`
 }
 
 // ViewMode represents the current view (board, table, or help)
@@ -133,6 +196,7 @@ const (
 	ViewBoard ViewMode = iota
 	ViewTable
 	ViewHelp
+	ViewFleet // Running/paused agents across the board
 )
 
 // FormMode represents the current form state
@@ -147,8 +211,80 @@ const (
 // Model is the Bubbletea model for the TUI application
 type Model struct {
 	// Data
-	board   *Board
-	backend Backend
+	board      *Board
+	backend    Backend
+	boardFile  string // Local board file (kept while the beads backend is active)
+	boardsDir  string // Directory scanned for boards by the switcher (defaults to boardFile's)
+	beadsJSONL bool   // Read beads boards from issues.jsonl (--beads-jsonl)
+
+	// Board switcher state
+	boardStates     map[string]boardViewState // Remembered selection/filter per board key
+	switcherActive  bool                      // Whether the board switcher overlay is open
+	switcherInput   textinput.Model           // Fuzzy search input for the switcher
+	switcherEntries []BoardEntry              // Boards found when the switcher was opened
+	switcherIndex   int                       // Highlighted entry in the filtered list
+
+	// Template picker state (shown when the board file doesn't exist yet)
+	templatePickerActive bool
+	templateChoices      []BoardTemplate
+	templateIndex        int
+
+	// Step prompt editor state
+	promptEditorActive bool
+	promptEditor       textarea.Model
+	promptEditorColumn string // ID of the column whose prompt is being edited
+
+	// Where chats are opened (tmux popup, zellij, this terminal...)
+	launcher Launcher
+
+	// Agents launched by this TUI
+	agents      *agentManager
+	rulesDryRun bool // Report auto-advance moves instead of making them (--rules-dry-run)
+	fleetIndex  int  // Highlighted row in the fleet view
+
+	// Multi-selection and the batch overlay
+	marked      map[string]bool // IDs of marked tasks
+	markAnchor  string          // Task range selection (V) starts from
+	batchActive bool
+	batch       batchState
+
+	// Swimlanes (see lanes.go)
+	laneMode       LaneGrouping
+	selectedLane   int             // Lane selected when no card is (empty or collapsed cell)
+	collapsedLanes map[string]bool // "grouping:key" of collapsed lanes
+
+	// Status picker (in the detail panel) and defer prompt, see status.go
+	statusPickerActive bool
+	statusPickerIndex  int
+	statusPickerPanel  bool // Detail panel was open before the picker
+	deferActive        bool
+	deferInput         textinput.Model
+	deferTaskID        string
+
+	// Resolution prompt for cards being closed, see resolution.go
+	resolveActive   bool
+	resolveTaskIDs  []string
+	resolveColumnID string
+	resolveKind     int
+	resolveInput    textinput.Model
+
+	// Comment prompt, see comments.go
+	commentActive bool
+	commentInput  textinput.Model
+	commentTaskID string
+
+	// Export prompt (X), see export.go
+	exportActive bool
+	exportFormat int // Index into exportFormats
+	exportInput  textinput.Model
+
+	// Command palette state
+	paletteActive bool
+	paletteInput  textinput.Model
+	paletteIndex  int // Highlighted item in the filtered list
+
+	// One-line message shown in the status bar until the next key press
+	statusMessage string
 
 	// UI State
 	viewMode       ViewMode
@@ -179,6 +315,7 @@ type Model struct {
 	// Drop target tracking (for visual feedback)
 	dropTargetColumn int // Column where task would be dropped (-1 if none)
 	dropTargetIndex  int // Position where task would be inserted
+	dropTargetLane   int // Swimlane where task would be dropped (-1 if none)
 
 	// Scroll state per column (tracks first visible task index)
 	columnScrollOffset map[int]int
@@ -194,7 +331,7 @@ type Model struct {
 	deletingTaskID   string // ID of task pending deletion
 
 	// Quick-add form state
-	formIssueType string   // Selected issue type: task, bug, feature
+	formIssueType string   // Selected issue type, one of issueTypes
 	formPriority  Priority // Selected priority level
 
 	// Double-click detection
@@ -206,6 +343,7 @@ type Model struct {
 	filterActive bool            // Whether filter mode is active
 	filterInput  textinput.Model // Text input for filtering
 	filterText   string          // Current filter text (applied when Enter pressed)
+	epicFocus    string          // Epic being drilled into (E); other cards are ghosted
 
 	// Responsive layout state
 	narrowMode         bool // Whether we're in narrow/responsive mode
@@ -217,6 +355,7 @@ type Model struct {
 	cachedIssueDetails *BeadsIssueDetails // Cached full details for selected issue
 	cachedIssueID      string             // ID of issue with cached details
 	detailScrollOffset int                // Scroll offset within detail panel
+	detailScrollTaskID string             // Task the scroll offset belongs to
 }
 
 // boardLoadedMsg is sent when the board has been loaded
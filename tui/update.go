@@ -40,6 +40,25 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case agentOutputMsg:
+		m.handleAgentOutput(msg)
+		return m, m.agents.waitForEvent()
+
+	case agentExitedMsg:
+		m.handleAgentExited(msg)
+		return m, m.agents.waitForEvent()
+
+	case chatFinishedMsg:
+		m.handleChatFinished(msg)
+		return m, nil
+
+	case fleetTickMsg:
+		// Keep ticking only while the fleet view is open
+		if m.viewMode != ViewFleet {
+			return m, nil
+		}
+		return m, fleetTick()
+
 	case boardLoadedMsg:
 		if msg.err != nil {
 			// Handle error - for now just keep current board
@@ -7,11 +7,29 @@ import (
 
 // handleKeyMsg handles keyboard input
 func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Any key dismisses the status bar message
+	m.statusMessage = ""
+
 	// Handle filter input first if filter is active
 	if m.filterActive {
 		return m.handleFilterKeyMsg(msg)
 	}
 
+	// Handle template picker input
+	if m.templatePickerActive {
+		return m.handleTemplatePickerKeyMsg(msg)
+	}
+
+	// Handle step prompt editor input
+	if m.promptEditorActive {
+		return m.handlePromptEditorKeyMsg(msg)
+	}
+
+	// Handle board switcher input
+	if m.switcherActive {
+		return m.handleSwitcherKeyMsg(msg)
+	}
+
 	// Handle form input first if form is open
 	if m.formMode != FormNone {
 		return m.handleFormKeyMsg(msg)
@@ -22,26 +40,42 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleDeleteConfirmation(msg)
 	}
 
-	// Global shortcuts
-	switch msg.String() {
-	case "q", "ctrl+c":
-		return m, tea.Quit
+	// Handle the batch overlay
+	if m.batchActive {
+		return m.handleBatchKeyMsg(msg)
+	}
 
-	case "?":
-		// Toggle help screen
-		if m.viewMode == ViewHelp {
-			// Return to previous view
-			m.viewMode = m.previousView
-		} else {
-			// Show help
-			m.previousView = m.viewMode
-			m.viewMode = ViewHelp
-		}
-		return m, nil
+	// Handle the command palette
+	if m.paletteActive {
+		return m.handlePaletteKeyMsg(msg)
+	}
 
-	case "tab":
-		m.toggleDetails()
-		return m, nil
+	// Handle the status picker and defer prompt
+	if m.statusPickerActive {
+		return m.handleStatusPickerKeyMsg(msg)
+	}
+	if m.deferActive {
+		return m.handleDeferKeyMsg(msg)
+	}
+
+	// Handle the resolution prompt
+	if m.resolveActive {
+		return m.handleResolutionKeyMsg(msg)
+	}
+
+	// Handle the comment prompt
+	if m.commentActive {
+		return m.handleCommentKeyMsg(msg)
+	}
+
+	// Handle the export prompt
+	if m.exportActive {
+		return m.handleExportKeyMsg(msg)
+	}
+
+	// Global shortcuts (quit, help, detail panel)
+	if c := commandForKey(msg.String(), true); c != nil {
+		return m.runCommand(c)
 	}
 
 	// View-specific shortcuts
@@ -50,117 +84,19 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleBoardKeyMsg(msg)
 	case ViewHelp:
 		return m.handleHelpKeyMsg(msg)
+	case ViewFleet:
+		return m.handleFleetKeyMsg(msg)
 	}
 
 	return m, nil
 }
 
 // handleBoardKeyMsg handles keyboard input for board view
+// Board keys are bound in the command registry (commands.go)
 func (m Model) handleBoardKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	// Navigation
-	case "left", "h":
-		m.moveSelectionLeft()
-		m.updateScrollOffset()
-		m.fetchIssueDetails() // Refresh detail panel
-		return m, nil
-
-	case "right", "l":
-		m.moveSelectionRight()
-		m.updateScrollOffset()
-		m.fetchIssueDetails() // Refresh detail panel
-		return m, nil
-
-	case "up", "k":
-		m.moveSelectionUp()
-		m.updateScrollOffset()
-		m.fetchIssueDetails() // Refresh detail panel
-		return m, nil
-
-	case "down", "j":
-		m.moveSelectionDown()
-		m.updateScrollOffset()
-		m.fetchIssueDetails() // Refresh detail panel
-		return m, nil
-
-	// Jump to first/last column
-	case "home", "g":
-		m.selectedColumn = 0
-		m.selectedTask = 0
-		m.updateScrollOffset()
-		m.fetchIssueDetails() // Refresh detail panel
-		return m, nil
-
-	case "end", "G":
-		m.selectedColumn = len(m.board.Columns) - 1
-		m.selectedTask = 0
-		m.updateScrollOffset()
-		m.fetchIssueDetails() // Refresh detail panel
-		return m, nil
-
-	// Task creation and editing
-	case "n":
-		// New task
-		m.openCreateTaskForm()
-		return m, nil
-
-	case "e", "enter":
-		// Edit task (Enter also selects/opens the task)
-		m.openEditTaskForm()
-		return m, nil
-
-	case "d":
-		// Show delete confirmation
-		task := m.getCurrentTask()
-		if task != nil {
-			m.confirmingDelete = true
-			m.deletingTaskID = task.ID
-		}
-		return m, nil
-
-	case "m":
-		// Move task to next column (quick move)
-		m.moveTaskToNextColumn()
-		return m, nil
-
-	case "M":
-		// Move task to previous column
-		m.moveTaskToPrevColumn()
-		return m, nil
-
-	case "/":
-		// Open filter input
-		m.openFilter()
-		return m, nil
-
-	case "esc":
-		// Clear filter if active
-		if m.filterText != "" {
-			m.filterText = ""
-			return m, nil
-		}
-
-	case "c":
-		// Launch Claude chat popup with task context
-		task := m.getCurrentTask()
-		if task != nil {
-			// Try to get beads details for richer context
-			details := m.fetchIssueDetails()
-			go launchChatPopup(task, details)
-		}
-		return m, nil
-
-	case "B":
-		// Toggle between beads and local backend
-		m.toggleBackend()
-		return m, nil
-
-	case "A":
-		// Toggle showing closed/done issues (beads only)
-		m.toggleShowAll()
-		return m, nil
+	if c := commandForKey(msg.String(), false); c != nil {
+		return m.runCommand(c)
 	}
-
 	return m, nil
 }
 
@@ -195,7 +131,7 @@ func (m Model) handleFilterKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 func (m *Model) openFilter() {
 	m.filterActive = true
 	m.filterInput = textinput.New()
-	m.filterInput.Placeholder = "Filter tasks..."
+	m.filterInput.Placeholder = "Filter tasks... (#label)"
 	m.filterInput.CharLimit = 100
 	m.filterInput.Width = 30
 	m.filterInput.SetValue(m.filterText)
@@ -207,6 +143,102 @@ func (m *Model) closeFilter() {
 	m.filterActive = false
 }
 
+// handleSwitcherKeyMsg handles keyboard input when the board switcher is open
+func (m Model) handleSwitcherKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.closeBoardSwitcher()
+		return m, nil
+
+	case "up", "ctrl+p", "ctrl+k":
+		if m.switcherIndex > 0 {
+			m.switcherIndex--
+		}
+		return m, nil
+
+	case "down", "ctrl+n", "ctrl+j":
+		if m.switcherIndex < len(m.filteredBoardEntries())-1 {
+			m.switcherIndex++
+		}
+		return m, nil
+
+	case "enter":
+		entries := m.filteredBoardEntries()
+		if m.switcherIndex >= 0 && m.switcherIndex < len(entries) {
+			entry := entries[m.switcherIndex]
+			if entry.Key != m.currentBoardKey() {
+				if err := m.switchBoard(entry); err != nil {
+					m.statusMessage = "Switch failed: " + err.Error()
+				}
+			}
+		}
+		m.closeBoardSwitcher()
+		return m, nil
+	}
+
+	// Update the search input and keep the highlight in range
+	m.switcherInput, cmd = m.switcherInput.Update(msg)
+	if n := len(m.filteredBoardEntries()); m.switcherIndex >= n {
+		m.switcherIndex = n - 1
+	}
+	if m.switcherIndex < 0 {
+		m.switcherIndex = 0
+	}
+	return m, cmd
+}
+
+// handleTemplatePickerKeyMsg handles keyboard input when the template picker is open
+func (m Model) handleTemplatePickerKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "esc":
+		// Keep the default board without writing a file
+		m.templatePickerActive = false
+		return m, nil
+
+	case "up", "k":
+		if m.templateIndex > 0 {
+			m.templateIndex--
+		}
+		return m, nil
+
+	case "down", "j":
+		if m.templateIndex < len(m.templateChoices)-1 {
+			m.templateIndex++
+		}
+		return m, nil
+
+	case "enter":
+		m.applyTemplate()
+		m.templatePickerActive = false
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// handlePromptEditorKeyMsg handles keyboard input when the step prompt editor is open
+func (m Model) handlePromptEditorKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "esc":
+		m.closePromptEditor()
+		return m, nil
+
+	case "ctrl+s":
+		m.savePromptEditor()
+		return m, nil
+	}
+
+	m.promptEditor, cmd = m.promptEditor.Update(msg)
+	return m, cmd
+}
+
 // handleHelpKeyMsg handles keyboard input for help view
 func (m Model) handleHelpKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
@@ -219,6 +251,54 @@ func (m Model) handleHelpKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// handleFleetKeyMsg handles keyboard input in the agent fleet view
+func (m Model) handleFleetKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Agents may have exited since the last key press
+	count := len(m.fleetEntries())
+	if m.fleetIndex >= count {
+		m.fleetIndex = count - 1
+	}
+	if m.fleetIndex < 0 {
+		m.fleetIndex = 0
+	}
+
+	switch msg.String() {
+	case "esc", "F":
+		m.viewMode = ViewBoard
+		return m, nil
+
+	case "up", "k":
+		if m.fleetIndex > 0 {
+			m.fleetIndex--
+		}
+		return m, nil
+
+	case "down", "j":
+		if m.fleetIndex < count-1 {
+			m.fleetIndex++
+		}
+		return m, nil
+
+	case "p":
+		m.setFleetAgentStatus(AgentPaused)
+		return m, nil
+
+	case "r":
+		m.setFleetAgentStatus(AgentRunning)
+		return m, nil
+
+	case "x":
+		m.killFleetAgent()
+		return m, nil
+
+	case "enter":
+		m.jumpToFleetTask()
+		return m, nil
+	}
+
+	return m, nil
+}
+
 // handleFormKeyMsg handles keyboard input when task form is open
 func (m Model) handleFormKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
@@ -235,27 +315,13 @@ func (m Model) handleFormKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case "ctrl+t", "alt+t", "}":
-		// Cycle issue type forward: task → bug → feature → task
-		switch m.formIssueType {
-		case "task":
-			m.formIssueType = "bug"
-		case "bug":
-			m.formIssueType = "feature"
-		default:
-			m.formIssueType = "task"
-		}
+		// Cycle issue type forward: task → bug → feature → epic → chore → task
+		m.formIssueType = cycleIssueType(m.formIssueType, 1)
 		return m, nil
 
 	case "{":
-		// Cycle issue type backward: task → feature → bug → task
-		switch m.formIssueType {
-		case "task":
-			m.formIssueType = "feature"
-		case "feature":
-			m.formIssueType = "bug"
-		default:
-			m.formIssueType = "task"
-		}
+		// Cycle issue type backward
+		m.formIssueType = cycleIssueType(m.formIssueType, -1)
 		return m, nil
 
 	case "ctrl+p", "alt+p", "[":
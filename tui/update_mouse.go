@@ -34,6 +34,15 @@ func (m Model) handleBoardMouseMsg(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 		if msg.Action == tea.MouseActionMotion && m.draggingTask != nil {
 			return m.handleMouseMotion(msg)
 		}
+	case tea.MouseButtonWheelUp, tea.MouseButtonWheelDown:
+		// The wheel scrolls the detail panel under the pointer
+		if m.showDetails && msg.X >= m.width-m.detailWidth {
+			if msg.Button == tea.MouseButtonWheelUp {
+				m.scrollDetails(-3)
+			} else {
+				m.scrollDetails(3)
+			}
+		}
 	}
 
 	return m, nil
@@ -42,7 +51,9 @@ func (m Model) handleBoardMouseMsg(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 // handleMouseMotion updates the drop target during drag
 func (m Model) handleMouseMotion(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 	// Only update drop target if actually dragging (not just potential drag)
-	if m.draggingTask != nil {
+	if m.draggingTask != nil && m.laneMode != LaneNone {
+		m.dropTargetLane, m.dropTargetColumn = m.getLaneDropPosition(msg.X, msg.Y)
+	} else if m.draggingTask != nil {
 		// Update drop target for visual feedback
 		colIndex, insertIndex := m.getDropPosition(msg.X, msg.Y)
 		m.dropTargetColumn = colIndex
@@ -103,6 +114,10 @@ func (m Model) handleMousePress(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	if m.laneMode != LaneNone {
+		return m.handleLaneMousePress(msg, colIndex)
+	}
+
 	if colIndex >= len(m.board.Columns) {
 		return m, nil
 	}
@@ -146,7 +161,16 @@ func (m Model) handleMouseRelease(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 		// Get drop position
 		toColIndex, insertIndex := m.getDropPosition(msg.X, msg.Y)
 
-		if toColIndex != -1 {
+		if m.laneMode != LaneNone {
+			// Swimlanes: the drop changes column and lane attribute
+			laneIndex, laneCol := m.getLaneDropPosition(msg.X, msg.Y)
+			if laneIndex != -1 && laneCol != -1 {
+				m.dropIntoLane(laneIndex, laneCol)
+			}
+		} else if toColIndex != -1 && m.isMarked(m.draggingTask) && len(m.marked) > 1 {
+			// Dragging a marked card moves the whole selection
+			m.dropMarkedTasks(toColIndex)
+		} else if toColIndex != -1 {
 			// Move task to the target position
 			m.moveTask(m.dragFromColumn, m.dragFromIndex, toColIndex, insertIndex)
 		}
@@ -155,6 +179,7 @@ func (m Model) handleMouseRelease(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 		m.draggingTask = nil
 		m.dropTargetColumn = -1
 		m.dropTargetIndex = -1
+		m.dropTargetLane = -1
 	}
 
 	// Clear potential drag state
@@ -352,3 +377,45 @@ func (m Model) getDropPosition(x, y int) (int, int) {
 	// Below all visible tasks - insert at end
 	return colIndex, len(col.Tasks)
 }
+
+// handleLaneMousePress selects the card (or lane) under the mouse in swimlane
+// mode; clicking a lane header collapses or expands the lane
+func (m Model) handleLaneMousePress(msg tea.MouseMsg, colIndex int) (tea.Model, tea.Cmd) {
+	const taskAreaStartY = 2
+	row, line, ok := m.laneAt(msg.Y - taskAreaStartY)
+	if !ok {
+		return m, nil
+	}
+
+	if line == 0 {
+		m.selectLaneTask(row.index, colIndex, nil)
+		m.toggleLaneCollapsed()
+		return m, nil
+	}
+
+	cell := m.lanes()[row.index].cells[colIndex]
+	task := laneTaskAt(cell, row.windows[colIndex], line-1)
+	m.selectLaneTask(row.index, colIndex, task)
+	m.ensureSelectedColumnVisible()
+	m.fetchIssueDetails() // Refresh detail panel
+	if task == nil {
+		return m, nil
+	}
+
+	// Store potential drag info but don't start dragging yet
+	m.potentialDrag = true
+	m.dragFromColumn = colIndex
+	m.dragFromIndex = m.selectedTask
+	return m, tickCmd()
+}
+
+// getLaneDropPosition returns the lane and column for a drop in swimlane mode
+func (m Model) getLaneDropPosition(x, y int) (laneIndex, colIndex int) {
+	const taskAreaStartY = 2
+	colIndex = m.getColumnAtPosition(x, y)
+	row, _, ok := m.laneAt(y - taskAreaStartY)
+	if colIndex == -1 || !ok {
+		return -1, -1
+	}
+	return row.index, colIndex
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -26,6 +27,8 @@ func (m Model) View() string {
 		return m.renderBoardView()
 	case ViewHelp:
 		return m.renderHelpView()
+	case ViewFleet:
+		return m.renderFleetView()
 	default:
 		return m.renderBoardView()
 	}
@@ -59,6 +62,51 @@ func (m Model) renderBoardView() string {
 		return m.renderFormOverlay(boardView)
 	}
 
+	// Render board switcher overlay if open
+	if m.switcherActive {
+		return m.renderBoardSwitcher(boardView)
+	}
+
+	// Render template picker overlay if open
+	if m.templatePickerActive {
+		return m.renderTemplatePicker(boardView)
+	}
+
+	// Render step prompt editor overlay if open
+	if m.promptEditorActive {
+		return m.renderPromptEditor(boardView)
+	}
+
+	// Render batch overlay if open
+	if m.batchActive {
+		return m.renderBatchMenu(boardView)
+	}
+
+	// Render command palette overlay if open
+	if m.paletteActive {
+		return m.renderPalette(boardView)
+	}
+
+	// Render defer prompt overlay if open (the status picker is in the detail panel)
+	if m.deferActive {
+		return m.renderDeferPrompt(boardView)
+	}
+
+	// Render resolution prompt overlay if open
+	if m.resolveActive {
+		return m.renderResolutionPrompt(boardView)
+	}
+
+	// Render comment prompt overlay if open
+	if m.commentActive {
+		return m.renderCommentPrompt(boardView)
+	}
+
+	// Render export prompt overlay if open
+	if m.exportActive {
+		return m.renderExportPrompt(boardView)
+	}
+
 	return boardView
 }
 
@@ -66,6 +114,9 @@ func (m Model) renderBoardView() string {
 func (m Model) renderTitle() string {
 	boardName := m.board.Name
 	viewLabel := "Board View"
+	if m.laneMode != LaneNone {
+		viewLabel = "Lanes: " + string(m.laneMode)
+	}
 
 	title := fmt.Sprintf("AI Kanban - %s", boardName)
 	titleStyle := styleTitle.Width(m.boardWidth)
@@ -106,8 +157,13 @@ func (m Model) renderBoard() string {
 	// Column headers
 	headers := m.renderColumnHeaders()
 
-	// Column contents (tasks stacked vertically)
-	columns := m.renderColumns(contentHeight)
+	// Column contents (tasks stacked vertically, or split into swimlanes)
+	var columns string
+	if m.laneMode != LaneNone {
+		columns = m.renderLanes(contentHeight)
+	} else {
+		columns = m.renderColumns(contentHeight)
+	}
 
 	// Join headers and columns
 	board := lipgloss.JoinVertical(lipgloss.Left, headers, columns)
@@ -142,6 +198,9 @@ func (m Model) renderColumnHeaders() string {
 		col := m.board.Columns[i]
 		count := len(col.Tasks)
 		label := fmt.Sprintf("%s (%d)", col.Title, count)
+		if col.Prompt != "" {
+			label += " ✎" // Column has a step prompt
+		}
 
 		// Get terminal color from Tailwind class
 		termColor := GetTerminalColor(col.Color)
@@ -306,6 +365,11 @@ func (m Model) renderColumn(col Column, colIndex int, contentHeight int, colWidt
 
 		// Check if this is the task being dragged
 		isDragging := m.draggingTask != nil && m.dragFromColumn == colIndex && i == m.dragFromIndex
+		if m.draggingTask != nil && m.isMarked(m.draggingTask) && m.isMarked(task) {
+			isDragging = true // The whole selection moves with a marked card
+		}
+
+		progress := m.epicProgress(task)
 
 		// Check if task matches filter (show as ghost if it doesn't match)
 		matchesFilter := m.taskMatchesFilter(task)
@@ -316,16 +380,16 @@ func (m Model) renderColumn(col Column, colIndex int, contentHeight int, colWidt
 		if showFullCard {
 			// Show full card
 			if isDragging || !matchesFilter {
-				columnContent.WriteString(renderCardGhost(task))
+				columnContent.WriteString(renderCardGhost(task, progress))
 			} else {
-				columnContent.WriteString(renderCard(task, isSelected))
+				columnContent.WriteString(renderCard(task, isSelected, m.isMarked(task), progress))
 			}
 		} else {
 			// Stacked task - show only top 2 lines
 			if isDragging || !matchesFilter {
-				columnContent.WriteString(renderCardTopLinesGhost(task))
+				columnContent.WriteString(renderCardTopLinesGhost(task, progress))
 			} else {
-				columnContent.WriteString(renderCardTopLines(task, isSelected))
+				columnContent.WriteString(renderCardTopLines(task, isSelected, m.isMarked(task), progress))
 			}
 			columnContent.WriteString("\n")
 		}
@@ -350,8 +414,17 @@ func (m Model) renderColumn(col Column, colIndex int, contentHeight int, colWidt
 		Render(columnContent.String())
 }
 
-// renderDetailPanel renders the detail panel for the selected task
+// renderDetailPanel renders the detail panel for the selected task,
+// scrolled with J/K
 func (m Model) renderDetailPanel() string {
+	return styleDetailPanel.
+		Width(m.detailWidth - 2).
+		Height(m.getContentHeight()).
+		Render(m.scrollDetailContent(m.renderDetailContent()))
+}
+
+// renderDetailContent renders the detail panel's content, unscrolled
+func (m Model) renderDetailContent() string {
 	task := m.getCurrentTask()
 	panelWidth := m.detailWidth - 2
 	contentWidth := panelWidth - 6 // Account for borders and padding
@@ -383,14 +456,28 @@ func (m Model) renderDetailPanel() string {
 		content.WriteString(" " + task.Priority.String())
 		content.WriteString("\n\n")
 
-		// Labels/Type
-		if len(task.Labels) > 0 {
+		// Status, with the picker when open
+		content.WriteString(m.renderStatusSection(task, details, contentWidth))
+
+		// Type, then labels and state dimensions
+		if issueType := taskIssueType(task); issueType != "" {
 			content.WriteString(styleDetailLabel.Render("Type: "))
-			for _, label := range task.Labels {
-				content.WriteString(styleLabel.Render(label))
-			}
+			content.WriteString(styleLabel.Render(issueType))
 			content.WriteString("\n\n")
 		}
+		content.WriteString(renderLabelSection(task, contentWidth))
+
+		// Assignee, due date and estimate, when set
+		planning := []struct{ label, value string }{
+			{"Assignee: ", task.Assignee}, {"Due: ", task.DueDate}, {"Estimate: ", task.Estimate},
+		}
+		for _, field := range planning {
+			if field.value != "" {
+				content.WriteString(styleDetailLabel.Render(field.label))
+				content.WriteString(styleDetailValue.Render(sanitizeLine(field.value)))
+				content.WriteString("\n\n")
+			}
+		}
 
 		// Description (with word wrapping)
 		if task.Description != "" {
@@ -401,11 +488,44 @@ func (m Model) renderDetailPanel() string {
 			content.WriteString("\n\n")
 		}
 
+		// Parent epic
+		if task.Parent != "" {
+			parent := task.Parent
+			if epic := findTaskByID(m.board, task.Parent); epic != nil {
+				parent += " " + epic.Title
+			}
+			content.WriteString(styleDetailLabel.Render("Epic: "))
+			content.WriteString(styleDetailValue.Render(truncateText(sanitizeLine(parent), contentWidth-6)))
+			content.WriteString("\n\n")
+		}
+
+		// Children of an epic with their progress
+		if isEpic(task) {
+			progress := m.epicProgress(task)
+			content.WriteString(styleDetailLabel.Render("Children: "))
+			content.WriteString(renderProgressBar(progress, 20, false))
+			content.WriteString("\n")
+			for _, child := range m.childrenOf(task.ID) {
+				statusIcon := "○"
+				if m.isDoneColumn(child.ColumnID) {
+					statusIcon = "✓"
+				}
+				line := fmt.Sprintf("  %s %s", statusIcon, sanitizeLine(child.Title))
+				content.WriteString(styleSubdued.Render(truncateText(line, contentWidth)))
+				content.WriteString("\n")
+			}
+			if task.HiddenChildren > 0 {
+				content.WriteString(styleSubdued.Render(fmt.Sprintf("  +%d closed (A shows all)", task.HiddenChildren)))
+				content.WriteString("\n")
+			}
+			content.WriteString("\n")
+		}
+
 		// Dependencies section (from bd show details)
-		if details != nil && len(details.Dependencies) > 0 {
+		if details != nil && len(details.Blockers()) > 0 {
 			content.WriteString(styleDetailLabel.Render("⛔ Blocked By:"))
 			content.WriteString("\n")
-			for _, dep := range details.Dependencies {
+			for _, dep := range details.Blockers() {
 				statusIcon := "○"
 				if dep.Status == "closed" {
 					statusIcon = "✓"
@@ -428,10 +548,10 @@ func (m Model) renderDetailPanel() string {
 		}
 
 		// Dependents section (issues this blocks)
-		if details != nil && len(details.Dependents) > 0 {
+		if details != nil && len(details.Blocked()) > 0 {
 			content.WriteString(styleDetailLabel.Render("🚫 Blocking:"))
 			content.WriteString("\n")
-			for _, dep := range details.Dependents {
+			for _, dep := range details.Blocked() {
 				statusIcon := "○"
 				if dep.Status == "closed" {
 					statusIcon = "✓"
@@ -461,6 +581,59 @@ func (m Model) renderDetailPanel() string {
 			content.WriteString("\n\n")
 		}
 
+		// Recent history (auto-advance moves etc.)
+		if len(task.History) > 0 {
+			content.WriteString(styleDetailLabel.Render("History:"))
+			content.WriteString("\n")
+			start := len(task.History) - 3
+			if start < 0 {
+				start = 0
+			}
+			for _, entry := range task.History[start:] {
+				line := entry.Event
+				if entry.To != "" {
+					line += fmt.Sprintf(" %s → %s", entry.From, entry.To)
+				}
+				if entry.Detail != "" {
+					line += " (" + entry.Detail + ")"
+				}
+				content.WriteString(styleSubdued.Render(fmt.Sprintf("  %s %s",
+					formatRelativeTime(entry.At), truncateText(line, contentWidth-12))))
+				content.WriteString("\n")
+			}
+			content.WriteString("\n")
+		}
+
+		// Agent sessions, newest first (c resumes the marked one)
+		if len(task.Sessions) > 0 {
+			content.WriteString(styleDetailLabel.Render("Sessions:"))
+			content.WriteString("\n")
+			resumable := latestSession(task, chatAgentType(m.columnForTask(task)))
+			if resumable != nil && liveRunSession(task, resumable.ID) {
+				resumable = nil // c won't resume a run that is still going
+			}
+			shown := 0
+			for i := len(task.Sessions) - 1; i >= 0 && shown < 3; i-- {
+				session := task.Sessions[i]
+				marker := " "
+				if resumable != nil && session.ID == resumable.ID {
+					marker = "▸"
+				}
+				line := fmt.Sprintf("%s %s %s %s", marker, shortSessionID(session.ID), session.Kind, formatRelativeTime(session.LastUsed))
+				if session.Column != "" {
+					line += " · " + session.Column
+				}
+				content.WriteString(styleSubdued.Render(truncateText(line, contentWidth)))
+				content.WriteString("\n")
+				shown++
+			}
+			if len(task.Sessions) > shown {
+				content.WriteString(styleSubdued.Render(fmt.Sprintf("  +%d older", len(task.Sessions)-shown)))
+				content.WriteString("\n")
+			}
+			content.WriteString("\n")
+		}
+
 		// Git info
 		if task.Git != nil && task.Git.Branch != "" {
 			content.WriteString(styleDetailLabel.Render("Branch: "))
@@ -495,12 +668,13 @@ func (m Model) renderDetailPanel() string {
 			content.WriteString(styleSubdued.Render(fmt.Sprintf("Updated: %s",
 				formatRelativeTime(task.UpdatedAt))))
 		}
+
+		// Comment thread
+		content.WriteString("\n\n")
+		content.WriteString(m.renderComments(task, details, contentWidth))
 	}
 
-	return styleDetailPanel.
-		Width(panelWidth).
-		Height(m.getContentHeight()).
-		Render(content.String())
+	return content.String()
 }
 
 // wrapText wraps text to fit within maxWidth characters
@@ -628,6 +802,17 @@ func (m Model) renderStatus() string {
 		filterInfo = fmt.Sprintf(" | Filter: %s", m.filterText)
 	}
 
+	// Show the epic being drilled into with its progress
+	if epic := m.focusedEpic(); epic != nil {
+		progress := m.epicProgress(epic)
+		filterInfo += fmt.Sprintf(" | Epic: %s %d/%d", truncateText(sanitizeLine(epic.Title), 20), progress.done, progress.total)
+	}
+
+	// Show how many cards are marked for batch operations
+	if count := len(m.markedTasks()); count > 0 {
+		filterInfo += fmt.Sprintf(" | %d selected (x batch)", count)
+	}
+
 	// Show narrow mode indicator with column position
 	var narrowInfo string
 	if m.narrowMode {
@@ -643,16 +828,24 @@ func (m Model) renderStatus() string {
 
 	// Show backend indicator
 	backendHint := " [YAML]"
-	if m.isBeadsBackend() {
+	if beadsBackend, ok := m.backend.(*BeadsBackend); ok {
+		source := "beads"
+		if beadsBackend.Source() == beadsSourceJSONL {
+			source = "beads:jsonl" // Read straight from .beads/issues.jsonl
+		}
 		if m.isShowingAll() {
-			backendHint = " [beads:all]"
-		} else {
-			backendHint = " [beads]"
+			source += ":all"
 		}
+		backendHint = " [" + source + "]"
 	}
 
 	status := fmt.Sprintf("%s | %s%s%s%s | A All | ? Help | q", backendHint, colName, narrowInfo, taskInfo, filterInfo)
 
+	// Messages (auto-advance results, errors) replace the hints until the next key
+	if m.statusMessage != "" {
+		status = fmt.Sprintf("%s | %s", backendHint, m.statusMessage)
+	}
+
 	return styleStatus.Width(m.width).Render(status)
 }
 
@@ -685,12 +878,19 @@ func (m Model) renderFormOverlay(background string) string {
 	formContent.WriteString(styleDetailLabel.Render("Title:"))
 	formContent.WriteString("\n")
 	formContent.WriteString(m.formInputs[0].View())
-	formContent.WriteString("\n\n")
+	formContent.WriteString("\n")
+	if m.formMode == FormCreateTask {
+		if capture := parseCapture(m.formInputs[0].Value(), time.Now()); capture.hasTokens() {
+			formContent.WriteString(renderCapturePreview(capture))
+			formContent.WriteString("\n")
+		}
+	}
+	formContent.WriteString("\n")
 
 	// Type selector row
 	formContent.WriteString(styleDetailLabel.Render("Type:"))
 	formContent.WriteString("  ")
-	types := []string{"task", "bug", "feature"}
+	types := issueTypes
 	for i, t := range types {
 		if t == m.formIssueType {
 			formContent.WriteString(styleFormSelected.Render(t))
@@ -728,6 +928,23 @@ func (m Model) renderFormOverlay(background string) string {
 	formContent.WriteString(m.formInputs[1].View())
 	formContent.WriteString("\n\n")
 
+	// Labels input
+	if len(m.formInputs) > 2 {
+		formContent.WriteString(styleDetailLabel.Render("Labels:"))
+		formContent.WriteString(" ")
+		formContent.WriteString(styleSubdued.Render("(dimension:value for state)"))
+		formContent.WriteString("\n")
+		formContent.WriteString(m.formInputs[2].View())
+		formContent.WriteString("\n\n")
+	}
+
+	// New tasks join the epic being drilled into
+	if epic := m.focusedEpic(); epic != nil && m.formMode == FormCreateTask {
+		formContent.WriteString(styleDetailLabel.Render("Epic: "))
+		formContent.WriteString(styleDetailValue.Render(truncateText(sanitizeLine(epic.Title), 40)))
+		formContent.WriteString("\n\n")
+	}
+
 	// Help text
 	formContent.WriteString(styleSubdued.Render("Ctrl+T: Type | Ctrl+P: Priority | Ctrl+S: Save | Esc: Cancel"))
 
@@ -741,6 +958,138 @@ func (m Model) renderFormOverlay(background string) string {
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay)
 }
 
+// renderBoardSwitcher renders the fuzzy board switcher overlay
+func (m Model) renderBoardSwitcher(background string) string {
+	var content strings.Builder
+	content.WriteString(styleDetailTitle.Render("Switch Board"))
+	content.WriteString("\n\n")
+	content.WriteString(m.switcherInput.View())
+	content.WriteString("\n\n")
+
+	entries := m.filteredBoardEntries()
+	if len(entries) == 0 {
+		content.WriteString(styleSubdued.Render("No matching boards"))
+		content.WriteString("\n")
+	}
+
+	currentKey := m.currentBoardKey()
+	for i, entry := range entries {
+		source := filepath.Base(entry.Path)
+		if entry.IsBeads {
+			source = "beads"
+		}
+
+		marker := "  "
+		if entry.Key == currentKey {
+			marker = "● "
+		}
+		name := truncateText(entry.Name, 28)
+		count := ""
+		if entry.TaskCount >= 0 {
+			count = fmt.Sprint(entry.TaskCount)
+		}
+		line := fmt.Sprintf("%s%-28s %4s  %s", marker, name, count, truncateText(source, 14))
+
+		if i == m.switcherIndex {
+			content.WriteString(styleFormSelected.Render(line))
+		} else {
+			content.WriteString(styleDetailValue.Render(line))
+		}
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	content.WriteString(styleSubdued.Render("↑/↓: Select | Enter: Open | Esc: Cancel"))
+
+	overlay := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorPrimary).
+		Padding(1, 2).
+		Width(60).
+		Render(content.String())
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay)
+}
+
+// renderTemplatePicker renders the template picker shown for a new board
+func (m Model) renderTemplatePicker(background string) string {
+	var content strings.Builder
+	content.WriteString(styleDetailTitle.Render("New Board - Choose a Template"))
+	content.WriteString("\n\n")
+
+	for i, tmpl := range m.templateChoices {
+		line := fmt.Sprintf("%-16s %2d steps, %d AI", truncateText(tmpl.Name, 16), len(tmpl.Columns), tmpl.agentStepCount())
+		if i == m.templateIndex {
+			content.WriteString(styleFormSelected.Render(line))
+		} else {
+			content.WriteString(styleFormOption.Render(line))
+		}
+		content.WriteString("\n")
+	}
+
+	// Preview the highlighted template's pipeline
+	if m.templateIndex >= 0 && m.templateIndex < len(m.templateChoices) {
+		tmpl := m.templateChoices[m.templateIndex]
+		content.WriteString("\n")
+		content.WriteString(styleDetailValue.Render(wrapText(tmpl.Description, 52)))
+		content.WriteString("\n\n")
+
+		var steps []string
+		for _, col := range tmpl.Columns {
+			step := lipgloss.NewStyle().Foreground(GetTerminalColor(col.Color)).Render(col.Title)
+			if col.AssignedAgent != "" {
+				step += styleSubdued.Render("*")
+			}
+			steps = append(steps, step)
+		}
+		content.WriteString(strings.Join(steps, styleSubdued.Render(" → ")))
+		content.WriteString("\n")
+		content.WriteString(styleSubdued.Render("* AI-assisted step"))
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	content.WriteString(styleSubdued.Render("↑/↓: Select | Enter: Create | Esc: Default board"))
+
+	overlay := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorPrimary).
+		Padding(1, 2).
+		Width(60).
+		Render(content.String())
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay)
+}
+
+// renderPromptEditor renders the step prompt editor overlay
+func (m Model) renderPromptEditor(background string) string {
+	var content strings.Builder
+
+	title := "Step Prompt"
+	if col := findColumnByID(m.board, m.promptEditorColumn); col != nil {
+		title = fmt.Sprintf("Step Prompt - %s", col.Title)
+	}
+	content.WriteString(styleDetailTitle.Render(title))
+	content.WriteString("\n\n")
+	content.WriteString(m.promptEditor.View())
+	content.WriteString("\n\n")
+
+	content.WriteString(styleDetailLabel.Render("Placeholders"))
+	content.WriteString("\n")
+	content.WriteString(styleSubdued.Render(wrapText(strings.Join(promptPlaceholders, " "), 56)))
+	content.WriteString("\n\n")
+	content.WriteString(styleSubdued.Render("Ctrl+S: Save | Esc: Cancel | Empty: default prompt"))
+
+	overlay := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorPrimary).
+		Padding(1, 2).
+		Width(66).
+		Render(content.String())
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay)
+}
+
 // renderPriorityBadgeSelected renders a priority badge with full styling (selected)
 func (m Model) renderPriorityBadgeSelected(p Priority, label string) string {
 	var color lipgloss.Color
@@ -756,7 +1105,7 @@ func (m Model) renderPriorityBadgeSelected(p Priority, label string) string {
 	}
 	return lipgloss.NewStyle().
 		Background(color).
-		Foreground(lipgloss.Color("0")).
+		Foreground(colorOnAccent).
 		Bold(true).
 		Padding(0, 1).
 		Render(label)
@@ -775,40 +1124,12 @@ func (m Model) renderHelpView() string {
 	title := styleTitle.Width(m.width).Render("AI Kanban TUI - Help")
 	sections = append(sections, title)
 
-	helpContent := `
-NAVIGATION
-  h/l or ←/→          Move between columns
-  j/k or ↑/↓          Move between tasks
-  g / G               Jump to first/last column
-  Click header        Jump to column
-
-ACTIONS
-  Enter / e           Edit selected task
-  n                   New task (quick-add form)
-  d                   Delete task (confirm with y)
-  m / M               Move task right/left
-
-QUICK-ADD FORM (when open)
-  { / }               Cycle type: task/bug/feature
-  [ / ]               Cycle priority: P0-P3
-  Tab                 Next field
-  Enter (last field)  Save
-  Esc                 Cancel
-
-VIEW
-  Tab                 Toggle detail panel
-  /                   Filter tasks
-  A                   Toggle show all (incl. closed)
-  B                   Toggle beads/YAML backend
-  ?                   This help
-
-MOUSE
-  Click card          Select task
-  Drag card           Move between columns
-  Click header        Jump to column
-
-q to quit | Press any key to return...
-`
+	// Generated from the command registry so it shows the effective key bindings
+	helpContent := "\n" + commandHelp(19) + "\n"
+	if path, err := userConfigPath(); err == nil {
+		helpContent += "Keys and theme: " + path + "\n"
+	}
+	helpContent += "q to quit | Press any key to return...\n"
 
 	helpStyle := lipgloss.NewStyle().
 		Padding(2, 4).
@@ -819,23 +1140,23 @@ q to quit | Press any key to return...
 	return lipgloss.JoinVertical(lipgloss.Left, sections...)
 }
 
-// taskMatchesFilter checks if a task matches the current filter text
+// taskMatchesFilter checks if a task matches the current filter text and,
+// when drilled into an epic, is one of its children
 func (m Model) taskMatchesFilter(task *Task) bool {
+	if m.epicFocus != "" && task.Parent != m.epicFocus {
+		return false
+	}
 	if m.filterText == "" {
 		return true
 	}
 
-	filter := strings.ToLower(m.filterText)
-	title := strings.ToLower(task.Title)
-	desc := strings.ToLower(task.Description)
-
-	// Match against title or description
-	return strings.Contains(title, filter) || strings.Contains(desc, filter)
+	// Match against title, description and labels (#label for exact labels)
+	return matchesFilterText(task, m.filterText)
 }
 
 // getFilteredTasks returns tasks that match the current filter
 func (m Model) getFilteredTasks(col Column) []*Task {
-	if m.filterText == "" {
+	if m.filterText == "" && m.epicFocus == "" {
 		return col.Tasks
 	}
 